@@ -0,0 +1,505 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// The directive that marks a struct for code generation. It may be followed
+// by a comma-separated list of generators, e.g. "//gofunctools:gen lens,with".
+const directive = "//gofunctools:gen"
+
+// The available generators.
+const (
+	genLens    = "lens"
+	genWith    = "with"
+	genCurry   = "curry"
+	genEqual   = "equal"
+	genCompare = "compare"
+)
+
+var allGenerators = []string{genLens, genWith, genCurry, genEqual, genCompare}
+
+const opticsPath = "github.com/basbiezemans/gofunctools/optics"
+
+// A structInfo describes an annotated struct and the code to generate for it.
+type structInfo struct {
+	Name       string
+	Fields     []fieldInfo
+	Generators []string
+}
+
+// A fieldInfo describes a field of an annotated struct.
+type fieldInfo struct {
+	Name    string
+	Type    string
+	Param   string
+	Equal   string
+	Compare string
+	typ     types.Type
+}
+
+// EqualExpr returns an expression that compares the field of a and b for
+// equality.
+func (f fieldInfo) EqualExpr(a, b string) string {
+	return f.callExpr(f.Equal, "==", a, b)
+}
+
+// CompareExpr returns an expression that orders the field of a and b.
+func (f fieldInfo) CompareExpr(a, b string) string {
+	return f.callExpr(f.Compare, "", a, b)
+}
+
+func (f fieldInfo) callExpr(fn, op, a, b string) string {
+	x, y := a+"."+f.Name, b+"."+f.Name
+	switch {
+	case fn == "":
+		return x + " " + op + " " + y
+	case fn[0] == '.':
+		return x + fn + "(" + y + ")"
+	default:
+		return fn + "(" + x + ", " + y + ")"
+	}
+}
+
+// Has reports whether a generator is enabled for the struct.
+func (s structInfo) Has(gen string) bool {
+	return slices.Contains(s.Generators, gen)
+}
+
+// Args returns the parameter names of the fields as an argument list.
+func (s structInfo) Args() string {
+	var args []string
+	for _, f := range s.Fields {
+		args = append(args, f.Param)
+	}
+	return strings.Join(args, ", ")
+}
+
+// CurriedType returns the type of the curried constructor after the fields up
+// to and including field i have been applied.
+func (s structInfo) CurriedType(i int) string {
+	var sb strings.Builder
+	for _, f := range s.Fields[i+1:] {
+		fmt.Fprintf(&sb, "func(%s) ", f.Type)
+	}
+	sb.WriteString(s.Name)
+	return sb.String()
+}
+
+// Comparable reports whether all fields of the struct can be ordered, which
+// is required to generate a Compare function.
+func (s structInfo) Comparable() bool {
+	for _, f := range s.Fields {
+		if f.Compare == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// generate loads the package in the given directory and returns the generated
+// source code for all annotated structs. It returns nil if there are none.
+func generate(dir, output string) ([]byte, error) {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir, output)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+	imports := newImportSet(pkg)
+	var structs []structInfo
+	for _, file := range files {
+		infos, err := annotatedStructs(pkg, file, imports)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, infos...)
+	}
+	if len(structs) == 0 {
+		return nil, nil
+	}
+	if err := validateGenerators(structs); err != nil {
+		return nil, err
+	}
+	for _, s := range structs {
+		for i, f := range s.Fields {
+			if imports.hasName(f.Param) || shadows(pkg, f.Param) {
+				s.Fields[i].Param += "_"
+			}
+		}
+		if s.Has(genLens) {
+			imports.add(opticsPath, "optics")
+		}
+		if s.Has(genEqual) {
+			for _, f := range s.Fields {
+				imports.addEqual(f.typ)
+			}
+		}
+		if s.Has(genCompare) && s.Comparable() && slices.ContainsFunc(s.Fields, func(f fieldInfo) bool {
+			return f.Compare == "cmp.Compare"
+		}) {
+			imports.add("cmp", "cmp")
+		}
+	}
+	var buf bytes.Buffer
+	err = fileTemplate.Execute(&buf, map[string]any{
+		"Package": pkg.Name(),
+		"Imports": imports.sorted(),
+		"Structs": structs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// parseDir parses the non-test Go files in a directory, skipping the output
+// file of a previous run.
+func parseDir(fset *token.FileSet, dir, output string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") ||
+			strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// annotatedStructs collects the structs in a file that are marked with the
+// gofunctools:gen directive.
+func annotatedStructs(pkg *types.Package, file *ast.File, imports *importSet) ([]structInfo, error) {
+	var structs []structInfo
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if doc == nil && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+			gens, ok := parseDirective(doc)
+			if !ok {
+				continue
+			}
+			if ts.TypeParams != nil {
+				return nil, fmt.Errorf("%s: generic struct types are not supported", ts.Name.Name)
+			}
+			obj := pkg.Scope().Lookup(ts.Name.Name)
+			st, ok := obj.Type().Underlying().(*types.Struct)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s is not a struct type", directive, ts.Name.Name)
+			}
+			info := structInfo{Name: ts.Name.Name, Generators: gens}
+			for i := range st.NumFields() {
+				f := st.Field(i)
+				info.Fields = append(info.Fields, fieldInfo{
+					Name:    f.Name(),
+					Type:    imports.typeString(f.Type()),
+					Param:   paramName(f.Name()),
+					Equal:   equalFunc(f.Type()),
+					Compare: compareFunc(f.Type()),
+					typ:     f.Type(),
+				})
+			}
+			structs = append(structs, info)
+		}
+	}
+	return structs, nil
+}
+
+// parseDirective returns the generators requested by a doc comment, and
+// whether the doc comment contains the directive at all.
+func parseDirective(doc *ast.CommentGroup) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	for _, c := range doc.List {
+		rest, ok := strings.CutPrefix(c.Text, directive)
+		if !ok || (rest != "" && rest[0] != ' ') {
+			continue
+		}
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return allGenerators, true
+		}
+		var gens []string
+		for _, gen := range strings.Split(rest, ",") {
+			gens = append(gens, strings.TrimSpace(gen))
+		}
+		return gens, true
+	}
+	return nil, false
+}
+
+// validateGenerators reports unknown generator names.
+func validateGenerators(structs []structInfo) error {
+	var errs []error
+	for _, s := range structs {
+		for _, gen := range s.Generators {
+			if !slices.Contains(allGenerators, gen) {
+				errs = append(errs, fmt.Errorf("%s: unknown generator %q", s.Name, gen))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shadows reports whether a parameter name would hide a name that the
+// generated code may refer to: a declaration in the package itself, like a
+// field type, or a predeclared identifier, like string.
+func shadows(pkg *types.Package, name string) bool {
+	return pkg.Scope().Lookup(name) != nil || types.Universe.Lookup(name) != nil
+}
+
+// paramName derives a parameter name from a field name, e.g. "UserID" becomes
+// "userID". Names that collide with Go keywords get a trailing underscore.
+func paramName(name string) string {
+	rs := []rune(name)
+	for i := range rs {
+		if i > 0 && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+			break
+		}
+		rs[i] = unicode.ToLower(rs[i])
+	}
+	param := string(rs)
+	if token.IsKeyword(param) {
+		param += "_"
+	}
+	return param
+}
+
+// equalFunc returns the function used to compare two values of a type for
+// equality, or an empty string if the type is comparable with ==. A method
+// name starting with a dot means that the method is called on the first value.
+func equalFunc(t types.Type) string {
+	if hasMethod(t, "Equal", types.Typ[types.Bool]) {
+		return ".Equal"
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		if types.Comparable(u.Elem()) {
+			return "slices.Equal"
+		}
+	case *types.Map:
+		if types.Comparable(u.Elem()) {
+			return "maps.Equal"
+		}
+	default:
+		if types.Comparable(t) {
+			return ""
+		}
+	}
+	return "reflect.DeepEqual"
+}
+
+// compareFunc returns the function used to order two values of a type, or an
+// empty string if the type cannot be ordered.
+func compareFunc(t types.Type) string {
+	if b, ok := t.Underlying().(*types.Basic); ok && b.Info()&types.IsOrdered != 0 {
+		return "cmp.Compare"
+	}
+	if hasMethod(t, "Compare", types.Typ[types.Int]) {
+		return ".Compare"
+	}
+	return ""
+}
+
+// hasMethod reports whether a type has a method func(T) R with the given name,
+// like time.Time.Equal or time.Time.Compare.
+func hasMethod(t types.Type, name string, result types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 1 && types.Identical(sig.Params().At(0).Type(), t) &&
+		sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), result)
+}
+
+// An importSet keeps track of the packages referred to by generated code.
+type importSet struct {
+	pkg   *types.Package
+	paths map[string]string
+}
+
+func newImportSet(pkg *types.Package) *importSet {
+	return &importSet{pkg, make(map[string]string)}
+}
+
+func (s *importSet) add(path, name string) {
+	s.paths[path] = name
+}
+
+// hasName reports whether an imported package has the given name.
+func (s *importSet) hasName(name string) bool {
+	for _, n := range s.paths {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addEqual adds the package needed to compare values of a type for equality.
+func (s *importSet) addEqual(t types.Type) {
+	if fn := equalFunc(t); fn != "" && fn[0] != '.' {
+		pkg, _, _ := strings.Cut(fn, ".")
+		s.add(pkg, pkg)
+	}
+}
+
+// typeString formats a type relative to the generated package, adding the
+// packages it refers to.
+func (s *importSet) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == s.pkg {
+			return ""
+		}
+		s.add(p.Path(), p.Name())
+		return p.Name()
+	})
+}
+
+// sorted returns the import specs in the order goimports would list them:
+// standard library packages first, followed by other packages. An empty
+// string separates the two groups.
+func (s *importSet) sorted() []string {
+	var std, other []string
+	for path, name := range s.paths {
+		spec := fmt.Sprintf("%q", path)
+		if name != filepath.Base(path) {
+			spec = name + " " + spec
+		}
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+	if len(std) > 0 && len(other) > 0 {
+		std = append(std, "")
+	}
+	return append(std, other...)
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"setter": func(name string) string {
+		if token.IsExported(name) {
+			return "With" + name
+		}
+		return "with" + strings.ToUpper(name[:1]) + name[1:]
+	},
+}).Parse(`// Code generated by gofunctools-gen. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{end}}
+{{- range $s := .Structs}}
+{{- if $s.Has "lens"}}
+{{- range $s.Fields}}
+
+// {{$s.Name}}{{.Name}}Lens is a lens for the {{.Name}} field of {{$s.Name}}.
+func {{$s.Name}}{{.Name}}Lens() optics.Lens[{{$s.Name}}, {{.Type}}] {
+	return optics.NewLens(func(s {{$s.Name}}) {{.Type}} {
+		return s.{{.Name}}
+	}, func(v {{.Type}}, s {{$s.Name}}) {{$s.Name}} {
+		s.{{.Name}} = v
+		return s
+	})
+}
+{{- end}}
+{{- end}}
+{{- if $s.Has "with"}}
+{{- range $s.Fields}}
+
+// {{setter .Name}} returns a copy of {{$s.Name}} with the {{.Name}} field set to v.
+func (s {{$s.Name}}) {{setter .Name}}(v {{.Type}}) {{$s.Name}} {
+	s.{{.Name}} = v
+	return s
+}
+{{- end}}
+{{- end}}
+{{- if and ($s.Has "curry") $s.Fields}}
+
+// New{{$s.Name}} creates a new {{$s.Name}} from its fields.
+func New{{$s.Name}}({{range $i, $f := $s.Fields}}{{if $i}}, {{end}}{{$f.Param}} {{$f.Type}}{{end}}) {{$s.Name}} {
+	return {{$s.Name}}{ {{- $s.Args -}} }
+}
+
+// Curried{{$s.Name}} is the curried form of New{{$s.Name}}.
+func Curried{{$s.Name}}({{with index $s.Fields 0}}{{.Param}} {{.Type}}{{end}}) {{$s.CurriedType 0}} {
+{{- range $i, $f := $s.Fields}}{{if $i}}
+	return func({{$f.Param}} {{$f.Type}}) {{$s.CurriedType $i}} {
+{{- end}}{{end}}
+	return New{{$s.Name}}({{$s.Args}})
+{{- range $i, $f := $s.Fields}}{{if $i}}
+	}
+{{- end}}{{end}}
+}
+{{- end}}
+{{- if $s.Has "equal"}}
+
+// Equal{{$s.Name}} reports whether two values of {{$s.Name}} are equal.
+func Equal{{$s.Name}}(a, b {{$s.Name}}) bool {
+	return {{range $i, $f := $s.Fields}}{{if $i}} &&
+		{{end}}{{$f.EqualExpr "a" "b"}}{{else}}true{{end}}
+}
+{{- end}}
+{{- if and ($s.Has "compare") $s.Comparable}}
+
+// Compare{{$s.Name}} compares two values of {{$s.Name}} field by field. It
+// returns -1, 0 or +1, like cmp.Compare.
+func Compare{{$s.Name}}(a, b {{$s.Name}}) int {
+{{- range $s.Fields}}
+	if c := {{.CompareExpr "a" "b"}}; c != 0 {
+		return c
+	}
+{{- end}}
+	return 0
+}
+{{- end}}
+{{- end}}
+`))
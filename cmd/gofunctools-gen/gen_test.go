@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerateGolden(t *testing.T) {
+	dir := filepath.Join("testdata", "example")
+	golden := filepath.Join(dir, "example.golden")
+	have, err := generate(dir, "")
+	if err != nil {
+		t.Fatalf("generate(%q) failed: %v", dir, err)
+	}
+	if *update {
+		if err := os.WriteFile(golden, have, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(have) != string(want) {
+		t.Errorf("generate(%q) differs from %s, run go test -update to regenerate it:\n%s", dir, golden, have)
+	}
+}
+
+func TestGeneratedCodeTypeChecks(t *testing.T) {
+	dir := filepath.Join("testdata", "example")
+	src, err := generate(dir, "")
+	if err != nil {
+		t.Fatalf("generate(%q) failed: %v", dir, err)
+	}
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := parser.ParseFile(fset, "gofunctools_gen.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	pkg, err := conf.Check("example", fset, append(files, gen), info)
+	if err != nil {
+		t.Fatalf("generated code does not type-check: %v", err)
+	}
+	// Curried constructors with two fields have the type of Curry2(New<Type>)
+	curried := pkg.Scope().Lookup("CurriedAddress").Type().String()
+	if want := "func(street string) func(string) example.Address"; curried != want {
+		t.Errorf("CurriedAddress has type %s, expected %s", curried, want)
+	}
+	for _, name := range []string{"PersonFriendsLens", "EqualPerson", "CompareVersion", "NewEvent"} {
+		if pkg.Scope().Lookup(name) == nil {
+			t.Errorf("generated code does not declare %s", name)
+		}
+	}
+	for _, name := range []string{"ComparePerson", "VersionMajorLens", "NewVersion", "EqualPoint"} {
+		if pkg.Scope().Lookup(name) != nil {
+			t.Errorf("generated code declares %s, expected it to be omitted", name)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testcases := map[string]string{
+		"unknown": `unknown generator "bogus"`,
+		"generic": "generic struct types are not supported",
+	}
+	for name, expect := range testcases {
+		dir := filepath.Join("testdata", "errors", name)
+		_, err := generate(dir, "")
+		if err == nil || !strings.Contains(err.Error(), expect) {
+			t.Errorf("generate(%q) = %v, expected error containing %q", dir, err, expect)
+		}
+	}
+}
+
+func TestGenerateNothing(t *testing.T) {
+	dir := filepath.Join("testdata", "empty")
+	src, err := generate(dir, "")
+	if src != nil || err != nil {
+		t.Errorf("generate(%q) = %q, %v, expected nil, nil", dir, src, err)
+	}
+}
+
+func TestParamName(t *testing.T) {
+	testcases := map[string]string{
+		"Name":    "name",
+		"UserID":  "userID",
+		"ID":      "id",
+		"URLPath": "urlPath",
+		"Type":    "type_",
+		"x":       "x",
+	}
+	for input, expect := range testcases {
+		if result := paramName(input); result != expect {
+			t.Errorf("paramName(%q) = %q, expected %q", input, result, expect)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Command gofunctools-gen generates lenses, immutable setters, curried
+// constructors and Equal/Compare functions for annotated struct types.
+//
+// Usage:
+//
+//	gofunctools-gen [-output file] [dir]
+//
+// The package in dir (default ".") is loaded and type-checked. Every struct
+// type whose doc comment contains the directive
+//
+//	//gofunctools:gen
+//
+// gets the following declarations, written to a single file in dir:
+//
+//   - <Type><Field>Lens, an optics.Lens for each field
+//   - With<Field>, a method that returns a copy with the field updated
+//   - New<Type> and Curried<Type>, an uncurried and a curried constructor;
+//     for two and three fields, Curried<Type> is equivalent to Curry2 and
+//     Curry3 applied to New<Type>
+//   - Equal<Type>, which compares two values field by field
+//   - Compare<Type>, which orders two values field by field; it is only
+//     generated if all fields are ordered types
+//
+// The directive may be followed by a comma-separated list of generators to
+// limit the output, e.g. "//gofunctools:gen lens,with". The available
+// generators are lens, with, curry, equal and compare.
+//
+// The command is typically invoked with go generate:
+//
+//	//go:generate go run github.com/basbiezemans/gofunctools/cmd/gofunctools-gen
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	output := flag.String("output", "gofunctools_gen.go", "name of the output file")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: gofunctools-gen [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	path := *output
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	src, err := generate(dir, filepath.Base(path))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gofunctools-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if src == nil {
+		fmt.Fprintf(os.Stderr, "gofunctools-gen: no annotated structs in %s\n", dir)
+		return
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "gofunctools-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
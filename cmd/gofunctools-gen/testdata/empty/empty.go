@@ -0,0 +1,6 @@
+package empty
+
+// Point has no directive, so nothing is generated.
+type Point struct {
+	X, Y int
+}
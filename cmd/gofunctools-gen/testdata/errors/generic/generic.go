@@ -0,0 +1,6 @@
+package generic
+
+//gofunctools:gen
+type Box[T any] struct {
+	Value T
+}
@@ -0,0 +1,6 @@
+package unknown
+
+//gofunctools:gen lens,bogus
+type Point struct {
+	X, Y int
+}
@@ -0,0 +1,57 @@
+package example
+
+import "time"
+
+// Address is a postal address.
+//
+//gofunctools:gen
+type Address struct {
+	Street string
+	City   string
+}
+
+// Person has fields of various kinds.
+//
+//gofunctools:gen
+type Person struct {
+	Name    string
+	Born    time.Time
+	Address Address
+	Tags    []string
+	Scores  map[string]int
+	Friends []Person
+}
+
+//gofunctools:gen with,compare
+type Version struct {
+	Major, Minor, Patch int
+	label               string
+}
+
+// Point has no directive, so nothing is generated.
+type Point struct {
+	X, Y int
+}
+
+// Event has fields whose parameter names would clash with a package name and
+// a keyword.
+//
+//gofunctools:gen curry,equal,compare
+type Event struct {
+	Time time.Time
+	Type string
+}
+
+type node struct {
+	value int
+}
+
+// List has fields whose parameter names would clash with a type of the
+// package and a predeclared type.
+//
+//gofunctools:gen curry
+type List struct {
+	Node   node
+	Next   node
+	String string
+}
@@ -0,0 +1,114 @@
+package gofunctools
+
+// A Case is a branch of Cond: if the predicate holds, the function is applied.
+type Case[A, B any] struct {
+	If   func(A) bool
+	Then func(A) B
+}
+
+// Identity returns its argument.
+func Identity[A any](x A) A {
+	return x
+}
+
+// Const returns a unary function that ignores its argument and always
+// returns x.
+func Const[A, B any](x A) func(B) A {
+	return func(B) A {
+		return x
+	}
+}
+
+// Apply applies a unary function to a value. It is useful as a binary
+// function, e.g. to apply a slice of functions with ZipWith.
+func Apply[A, B any](fn func(A) B, x A) B {
+	return fn(x)
+}
+
+// Juxt returns a function that applies each function to its argument and
+// collects the results.
+func Juxt[A, B any](funcs ...func(A) B) func(A) []B {
+	return func(x A) []B {
+		var ys = make([]B, len(funcs))
+		for i, fn := range funcs {
+			ys[i] = fn(x)
+		}
+		return ys
+	}
+}
+
+// Fanout returns a function that applies two functions to its argument and
+// returns both results. See pair.Fanout for a variant that returns a Pair.
+func Fanout[A, B, C any](f1 func(A) B, f2 func(A) C) func(A) (B, C) {
+	return func(x A) (B, C) {
+		return f1(x), f2(x)
+	}
+}
+
+// Fanout3 returns a function that applies three functions to its argument
+// and returns the three results.
+func Fanout3[A, B, C, D any](f1 func(A) B, f2 func(A) C, f3 func(A) D) func(A) (B, C, D) {
+	return func(x A) (B, C, D) {
+		return f1(x), f2(x), f3(x)
+	}
+}
+
+// Converge returns a function that applies two functions to its argument and
+// combines the results with a binary function.
+func Converge[A, B, C, D any](combine func(B, C) D, f1 func(A) B, f2 func(A) C) func(A) D {
+	return func(x A) D {
+		return combine(f1(x), f2(x))
+	}
+}
+
+// Both converts a unary function to a function that applies it to each of
+// two arguments.
+func Both[A, B any](fn func(A) B) func(A, A) (B, B) {
+	return func(x, y A) (B, B) {
+		return fn(x), fn(y)
+	}
+}
+
+// On applies a binary function to the keys of its arguments, like Haskell's
+// `on`. For example, On(operators.LessThan[int], length) compares values by
+// length.
+func On[A, B, C any](binop func(B, B) C, key func(A) B) func(A, A) C {
+	return func(x, y A) C {
+		return binop(key(x), key(y))
+	}
+}
+
+// Cond returns a function that applies the function of the first case whose
+// predicate holds, or the otherwise function if none does.
+func Cond[A, B any](cases []Case[A, B], otherwise func(A) B) func(A) B {
+	return func(x A) B {
+		for _, c := range cases {
+			if c.If(x) {
+				return c.Then(x)
+			}
+		}
+		return otherwise(x)
+	}
+}
+
+// When returns a function that applies fn to its argument if the predicate
+// holds, and returns the argument unchanged otherwise.
+func When[A any](pred func(A) bool, fn func(A) A) func(A) A {
+	return func(x A) A {
+		if pred(x) {
+			return fn(x)
+		}
+		return x
+	}
+}
+
+// Unless returns a function that applies fn to its argument if the
+// predicate does not hold, and returns the argument unchanged otherwise.
+func Unless[A any](pred func(A) bool, fn func(A) A) func(A) A {
+	return func(x A) A {
+		if pred(x) {
+			return x
+		}
+		return fn(x)
+	}
+}
@@ -0,0 +1,98 @@
+package gofunctools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIdentityConstApply(t *testing.T) {
+	if Identity(42) != 42 {
+		t.Errorf("Identity(42) != 42")
+	}
+	if have := Const[string, int]("x")(7); have != "x" {
+		t.Errorf("Const(x)(7) = %q, expected \"x\"", have)
+	}
+	if have := Apply(strings.ToUpper, "go"); have != "GO" {
+		t.Errorf("Apply(ToUpper, go) = %q, expected \"GO\"", have)
+	}
+}
+
+func TestJuxtFanout(t *testing.T) {
+	stats := Juxt(strings.ToUpper, strings.ToLower, strings.TrimSpace)
+	if have, expect := stats(" Go "), []string{" GO ", " go ", "Go"}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("Juxt(...)(\" Go \") = %q, expected %q", have, expect)
+	}
+	length := func(s string) int { return len(s) }
+	upper, n := Fanout(strings.ToUpper, length)("abc")
+	if upper != "ABC" || n != 3 {
+		t.Errorf("Fanout(ToUpper, len)(abc) = %q, %d, expected \"ABC\", 3", upper, n)
+	}
+	fields := func(s string) []string { return strings.Fields(s) }
+	upper, n, words := Fanout3(strings.ToUpper, length, fields)("a b")
+	if upper != "A B" || n != 3 || len(words) != 2 {
+		t.Errorf("Fanout3(...)(\"a b\") = %q, %d, %q", upper, n, words)
+	}
+}
+
+func TestConverge(t *testing.T) {
+	sum := func(xs []float64) float64 {
+		var s float64
+		for _, x := range xs {
+			s += x
+		}
+		return s
+	}
+	count := func(xs []float64) float64 { return float64(len(xs)) }
+	divide := func(x, y float64) float64 { return x / y }
+	average := Converge(divide, sum, count)
+	if have := average([]float64{1, 2, 3, 4}); have != 2.5 {
+		t.Errorf("average([1 2 3 4]) = %v, expected 2.5", have)
+	}
+}
+
+func TestBothOn(t *testing.T) {
+	x, y := Both(strings.ToUpper)("a", "b")
+	if x != "A" || y != "B" {
+		t.Errorf("Both(ToUpper)(a, b) = %q, %q, expected \"A\", \"B\"", x, y)
+	}
+	length := func(s string) int { return len(s) }
+	shorter := On(func(x, y int) bool { return x < y }, length)
+	if !shorter("go", "rust") || shorter("haskell", "go") {
+		t.Errorf("On(<, len) gave wrong results")
+	}
+}
+
+func TestCond(t *testing.T) {
+	fizzbuzz := Cond([]Case[int, string]{
+		{func(n int) bool { return n%15 == 0 }, Const[string, int]("FizzBuzz")},
+		{func(n int) bool { return n%3 == 0 }, Const[string, int]("Fizz")},
+		{func(n int) bool { return n%5 == 0 }, Const[string, int]("Buzz")},
+	}, func(n int) string { return strings.Repeat(".", n) })
+	var have []string
+	for n := 1; n <= 15; n++ {
+		have = append(have, fizzbuzz(n))
+	}
+	expect := strings.Fields(". .. Fizz .... Buzz Fizz ....... ........ Fizz Buzz ........... Fizz ............. .............. FizzBuzz")
+	if !reflect.DeepEqual(have, expect) {
+		t.Errorf("fizzbuzz(1..15) = %v, expected %v", have, expect)
+	}
+}
+
+func TestWhenUnless(t *testing.T) {
+	long := func(s string) bool { return len(s) > 3 }
+	truncate := func(s string) string { return s[:3] }
+	if have := When(long, truncate)("golang"); have != "gol" {
+		t.Errorf("When(long, truncate)(golang) = %q, expected \"gol\"", have)
+	}
+	if have := When(long, truncate)("go"); have != "go" {
+		t.Errorf("When(long, truncate)(go) = %q, expected \"go\"", have)
+	}
+	exclaim := func(s string) string { return s + "!" }
+	if have := Unless(long, exclaim)("go"); have != "go!" {
+		t.Errorf("Unless(long, exclaim)(go) = %q, expected \"go!\"", have)
+	}
+	if have := Unless(long, exclaim)("golang"); have != "golang" {
+		t.Errorf("Unless(long, exclaim)(golang) = %q, expected \"golang\"", have)
+	}
+}
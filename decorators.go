@@ -0,0 +1,158 @@
+package gofunctools
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A Clock tells the time and creates timers. Debounce, Throttle and the
+// decorators of package resilience take a Clock, so they can be tested without
+// sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the real-time clock of the time package. Decorators use it
+// when they are given a nil Clock.
+var SystemClock Clock = systemClock{}
+
+// Once returns a function that calls fn only the first time it is called.
+// It is safe for concurrent use.
+func Once(fn func()) func() {
+	return sync.OnceFunc(fn)
+}
+
+// OnceValue returns a function that calls fn only the first time it is
+// called, and returns the same value every time. It is safe for concurrent
+// use.
+func OnceValue[A any](fn func() A) func() A {
+	return sync.OnceValue(fn)
+}
+
+// Tap returns a unary function that passes a call to fn and returns its
+// argument unchanged. It is used to observe the values that flow through a
+// Pipe, e.g. for logging.
+func Tap[A any](fn func(A)) func(A) A {
+	return func(x A) A {
+		fn(x)
+		return x
+	}
+}
+
+// Before returns a function that calls a hook with the argument, before it
+// calls fn.
+func Before[A, B any](hook func(A), fn func(A) B) func(A) B {
+	return func(x A) B {
+		hook(x)
+		return fn(x)
+	}
+}
+
+// After returns a function that calls fn, and then calls a hook with the
+// argument and the result.
+func After[A, B any](hook func(A, B), fn func(A) B) func(A) B {
+	return func(x A) B {
+		var y = fn(x)
+		hook(x, y)
+		return y
+	}
+}
+
+// Counted returns a function that counts its calls, together with a function
+// that returns the count. It is safe for concurrent use.
+func Counted[A, B any](fn func(A) B) (func(A) B, func() int) {
+	var n atomic.Int64
+	return func(x A) B {
+			n.Add(1)
+			return fn(x)
+		}, func() int {
+			return int(n.Load())
+		}
+}
+
+// Debounce returns a function that delays calls to fn until d has passed
+// without another call. Only the last call of a burst reaches fn, which runs
+// in a separate goroutine. A burst has a single timer, which is re-armed when
+// it fires before the last call is d old.
+func Debounce[A any](d time.Duration, clock Clock, fn func(A)) func(A) {
+	if clock == nil {
+		clock = SystemClock
+	}
+	var mu sync.Mutex
+	var last A
+	var deadline time.Time
+	var waiting = false
+	var wait = func() {
+		for {
+			mu.Lock()
+			var left = deadline.Sub(clock.Now())
+			if left <= 0 {
+				var x = last
+				waiting = false
+				mu.Unlock()
+				fn(x)
+				return
+			}
+			mu.Unlock()
+			<-clock.After(left)
+		}
+	}
+	return func(x A) {
+		mu.Lock()
+		defer mu.Unlock()
+		last, deadline = x, clock.Now().Add(d)
+		if !waiting {
+			waiting = true
+			go wait()
+		}
+	}
+}
+
+// Throttle returns a function that calls fn at most once per interval d. The
+// first call of an interval reaches fn; later calls in the same interval are
+// dropped.
+func Throttle[A any](d time.Duration, clock Clock, fn func(A)) func(A) {
+	if clock == nil {
+		clock = SystemClock
+	}
+	var mu sync.Mutex
+	var next time.Time
+	return func(x A) {
+		mu.Lock()
+		var now = clock.Now()
+		var ok = !now.Before(next)
+		if ok {
+			next = now.Add(d)
+		}
+		mu.Unlock()
+		if ok {
+			fn(x)
+		}
+	}
+}
+
+// Trace returns a function that logs each call to fn, with its argument,
+// result and duration, at info level. If logger is nil, the calls are logged
+// with slog.Default(), which shows info messages unless it is configured
+// otherwise.
+func Trace[A, B any](logger *slog.Logger, name string, fn func(A) B) func(A) B {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(x A) B {
+		var start = time.Now()
+		var y = fn(x)
+		logger.LogAttrs(context.Background(), slog.LevelInfo, name,
+			slog.Any("in", x), slog.Any("out", y), slog.Duration("duration", time.Since(start)))
+		return y
+	}
+}
@@ -0,0 +1,187 @@
+package gofunctools
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A fake clock whose timers fire when the clock is advanced. If armed is not
+// nil, it receives a value each time a timer is created.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+	armed  chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ch = make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{c.now.Add(d), ch})
+	if c.armed != nil {
+		c.armed <- struct{}{}
+	}
+	return ch
+}
+
+func (c *fakeClock) pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	var pending []fakeTimer
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.ch <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+func TestOnce(t *testing.T) {
+	calls := 0
+	init := Once(func() { calls += 1 })
+	init()
+	init()
+	if calls != 1 {
+		t.Errorf("Once called fn %d times, expected 1", calls)
+	}
+	value := OnceValue(func() int { calls += 1; return calls })
+	if value() != 2 || value() != 2 || calls != 2 {
+		t.Errorf("OnceValue called fn %d times, expected once more", calls-1)
+	}
+}
+
+func TestTapBeforeAfter(t *testing.T) {
+	var seen []string
+	record := func(s string) { seen = append(seen, s) }
+	slugify := Pipe(strings.TrimSpace, Tap(record), strings.ToLower, Tap(record))
+	if have := slugify("  Hello "); have != "hello" {
+		t.Errorf("slugify = %q, expected \"hello\"", have)
+	}
+	if expect := []string{"Hello", "hello"}; !reflect.DeepEqual(seen, expect) {
+		t.Errorf("tapped %v, expected %v", seen, expect)
+	}
+	seen = nil
+	itoa := After(func(n int, s string) { record("after " + s) },
+		Before(func(n int) { record("before " + strconv.Itoa(n)) }, strconv.Itoa))
+	if have := itoa(7); have != "7" {
+		t.Errorf("itoa(7) = %q, expected \"7\"", have)
+	}
+	if expect := []string{"before 7", "after 7"}; !reflect.DeepEqual(seen, expect) {
+		t.Errorf("hooks saw %v, expected %v", seen, expect)
+	}
+}
+
+func TestCounted(t *testing.T) {
+	double, count := Counted(func(n int) int { return 2 * n })
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() { defer wg.Done(); double(i) }()
+	}
+	wg.Wait()
+	if count() != 50 {
+		t.Errorf("count() = %d, expected 50", count())
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	clock := &fakeClock{armed: make(chan struct{}, 1)}
+	calls := make(chan string, 10)
+	save := Debounce(time.Second, clock, func(s string) { calls <- s })
+	save("a")
+	<-clock.armed
+	clock.advance(500 * time.Millisecond)
+	for range 100 {
+		save("ab")
+	}
+	// A burst has a single timer, which is re-armed when it fires too early
+	if n := clock.pending(); n != 1 {
+		t.Errorf("%d pending timers, expected 1", n)
+	}
+	clock.advance(500 * time.Millisecond)
+	<-clock.armed
+	save("abc")
+	clock.advance(500 * time.Millisecond)
+	<-clock.armed
+	if len(calls) != 0 {
+		t.Fatalf("fn was called with %q before the burst ended", <-calls)
+	}
+	clock.advance(500 * time.Millisecond)
+	if s := <-calls; s != "abc" {
+		t.Errorf("fn was called with %q, expected \"abc\"", s)
+	}
+	if n := clock.pending(); len(calls) != 0 || n != 0 {
+		t.Errorf("%d more calls and %d pending timers after the burst, expected none", len(calls), n)
+	}
+	// The next call starts a new burst
+	save("b")
+	<-clock.armed
+	clock.advance(time.Second)
+	if s := <-calls; s != "b" {
+		t.Errorf("fn was called with %q, expected \"b\"", s)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	clock := &fakeClock{}
+	var calls []int
+	send := Throttle(time.Second, clock, func(n int) { calls = append(calls, n) })
+	for i := range 10 {
+		send(i)
+		clock.advance(300 * time.Millisecond)
+	}
+	// Calls at 0, 0.3, ..., 2.7 seconds pass at 0, 1.2 and 2.4 seconds
+	if expect := []int{0, 4, 8}; !reflect.DeepEqual(calls, expect) {
+		t.Errorf("Throttle passed %v, expected %v", calls, expect)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	square := Trace(logger, "square", func(n int) int { return n * n })
+	if have := square(4); have != 16 {
+		t.Errorf("square(4) = %d, expected 16", have)
+	}
+	line := buf.String()
+	for _, want := range []string{"level=INFO", "msg=square", "in=4", "out=16", "duration="} {
+		if !strings.Contains(line, want) {
+			t.Errorf("log line %q does not contain %q", line, want)
+		}
+	}
+	// A nil logger logs with the default logger, at a level it shows
+	defer slog.SetDefault(slog.Default())
+	buf.Reset()
+	slog.SetDefault(logger)
+	Trace(nil, "cube", func(n int) int { return n * n * n })(2)
+	if line := buf.String(); !strings.Contains(line, "msg=cube") || !strings.Contains(line, "out=8") {
+		t.Errorf("Trace with a nil logger logged %q, expected the call to cube", line)
+	}
+}
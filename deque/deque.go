@@ -0,0 +1,139 @@
+// Package deque defines a double-ended queue, which supports adding and
+// removing elements at both ends in amortized constant time.
+package deque
+
+import "iter"
+
+// A Deque is a double-ended queue backed by a growable ring buffer. The zero
+// Deque is an empty deque, ready to use.
+type Deque[A any] struct {
+	buf  []A
+	head int // index of the front element in buf
+	n    int // number of elements
+}
+
+// Create an empty deque.
+func New[A any]() *Deque[A] {
+	return &Deque[A]{}
+}
+
+// Create a deque of the elements of an iterator, from front to back.
+func FromSeq[A any](seq iter.Seq[A]) *Deque[A] {
+	var d = New[A]()
+	for a := range seq {
+		d.PushBack(a)
+	}
+	return d
+}
+
+// Len returns the number of elements.
+func (d *Deque[A]) Len() int {
+	return d.n
+}
+
+// PushBack adds an element at the back.
+func (d *Deque[A]) PushBack(a A) {
+	d.grow()
+	d.buf[d.index(d.n)] = a
+	d.n += 1
+}
+
+// PushFront adds an element at the front.
+func (d *Deque[A]) PushFront(a A) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = a
+	d.n += 1
+}
+
+// PopFront removes and returns the front element, or false if the deque is
+// empty.
+func (d *Deque[A]) PopFront() (A, bool) {
+	var zero A
+	if d.n == 0 {
+		return zero, false
+	}
+	var a = d.buf[d.head]
+	d.buf[d.head] = zero // release the reference for the garbage collector
+	d.head = (d.head + 1) % len(d.buf)
+	d.n -= 1
+	return a, true
+}
+
+// PopBack removes and returns the back element, or false if the deque is
+// empty.
+func (d *Deque[A]) PopBack() (A, bool) {
+	var zero A
+	if d.n == 0 {
+		return zero, false
+	}
+	var i = d.index(d.n - 1)
+	var a = d.buf[i]
+	d.buf[i] = zero
+	d.n -= 1
+	return a, true
+}
+
+// Front returns the front element, or false if the deque is empty.
+func (d *Deque[A]) Front() (A, bool) {
+	if d.n == 0 {
+		var zero A
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element, or false if the deque is empty.
+func (d *Deque[A]) Back() (A, bool) {
+	if d.n == 0 {
+		var zero A
+		return zero, false
+	}
+	return d.buf[d.index(d.n-1)], true
+}
+
+// At returns the i-th element from the front. It panics if i is out of range.
+func (d *Deque[A]) At(i int) A {
+	if i < 0 || i >= d.n {
+		panic("deque index out of range")
+	}
+	return d.buf[d.index(i)]
+}
+
+// All returns an iterator over the elements from front to back.
+func (d *Deque[A]) All() iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for i := range d.n {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements from back to front.
+func (d *Deque[A]) Backward() iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for i := d.n - 1; i >= 0; i-- {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Convert the i-th position from the front to an index in buf.
+func (d *Deque[A]) index(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// Double the capacity of a full buffer, moving the elements to the start.
+func (d *Deque[A]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	var buf = make([]A, max(2*len(d.buf), 8))
+	var k = copy(buf, d.buf[d.head:])
+	copy(buf[k:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+}
@@ -0,0 +1,97 @@
+package deque
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDequeModel(t *testing.T) {
+	// Compare random operations with a slice
+	rng := rand.New(rand.NewPCG(1, 2))
+	var d Deque[int]
+	var model []int
+	for i := range 10_000 {
+		switch rng.IntN(4) {
+		case 0:
+			d.PushBack(i)
+			model = append(model, i)
+		case 1:
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+		case 2:
+			x, ok := d.PopFront()
+			if ok != (len(model) > 0) || (ok && x != model[0]) {
+				t.Fatalf("PopFront() = %v, %v, model %v", x, ok, model)
+			}
+			if ok {
+				model = model[1:]
+			}
+		case 3:
+			x, ok := d.PopBack()
+			if ok != (len(model) > 0) || (ok && x != model[len(model)-1]) {
+				t.Fatalf("PopBack() = %v, %v, model %v", x, ok, model)
+			}
+			if ok {
+				model = model[:len(model)-1]
+			}
+		}
+		if d.Len() != len(model) {
+			t.Fatalf("Len() = %d, expected %d", d.Len(), len(model))
+		}
+	}
+	if have := slices.Collect(d.All()); !slices.Equal(have, model) {
+		t.Errorf("All() = %v, expected %v", have, model)
+	}
+	slices.Reverse(model)
+	if have := slices.Collect(d.Backward()); !slices.Equal(have, model) {
+		t.Errorf("Backward() = %v, expected %v", have, model)
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	d := FromSeq(slices.Values([]string{"a", "b", "c"}))
+	front, _ := d.Front()
+	back, _ := d.Back()
+	if front != "a" || back != "c" || d.At(1) != "b" {
+		t.Errorf("Front, At(1), Back = %q, %q, %q, expected a, b, c", front, d.At(1), back)
+	}
+	empty := New[int]()
+	if _, ok := empty.Front(); ok {
+		t.Errorf("Front() on empty deque = true, expected false")
+	}
+	if _, ok := empty.Back(); ok {
+		t.Errorf("Back() on empty deque = true, expected false")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("At(3) did not panic")
+		}
+	}()
+	d.At(3)
+}
+
+func TestSlidingWindowMax(t *testing.T) {
+	// A monotonic deque of indices gives the maximum of each window
+	xs := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	k := 3
+	var maxes []int
+	var window Deque[int]
+	for i, x := range xs {
+		for j, ok := window.Back(); ok && xs[j] <= x; j, ok = window.Back() {
+			window.PopBack()
+		}
+		window.PushBack(i)
+		if j, _ := window.Front(); j <= i-k {
+			window.PopFront()
+		}
+		if i >= k-1 {
+			j, _ := window.Front()
+			maxes = append(maxes, xs[j])
+		}
+	}
+	if expect := []int{3, 3, 5, 5, 6, 7}; !reflect.DeepEqual(maxes, expect) {
+		t.Errorf("sliding window maxima = %v, expected %v", maxes, expect)
+	}
+}
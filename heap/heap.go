@@ -0,0 +1,123 @@
+// Package heap defines a priority queue that is ordered by a comparison
+// function. Unlike container/heap, it is generic and needs no interface
+// implementation.
+package heap
+
+import "iter"
+
+// A PriorityQueue is a binary min-heap: Pop returns the smallest element
+// according to the comparison function. For a max-heap, reverse the
+// comparison. The zero PriorityQueue is not usable; create one with New or
+// FromSeq.
+type PriorityQueue[A any] struct {
+	items []A
+	cmp   func(A, A) int
+}
+
+// Create an empty priority queue with a comparison function, which returns a
+// negative number, zero or a positive number like cmp.Compare.
+func New[A any](cmp func(A, A) int) *PriorityQueue[A] {
+	return &PriorityQueue[A]{cmp: cmp}
+}
+
+// Create a priority queue of the elements of an iterator. It runs in linear
+// time.
+func FromSeq[A any](cmp func(A, A) int, seq iter.Seq[A]) *PriorityQueue[A] {
+	var pq = New(cmp)
+	for a := range seq {
+		pq.items = append(pq.items, a)
+	}
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	return pq
+}
+
+// Len returns the number of elements.
+func (pq *PriorityQueue[A]) Len() int {
+	return len(pq.items)
+}
+
+// Push adds an element in O(log n) time.
+func (pq *PriorityQueue[A]) Push(a A) {
+	pq.items = append(pq.items, a)
+	pq.up(len(pq.items) - 1)
+}
+
+// Peek returns the smallest element without removing it, or false if the
+// queue is empty.
+func (pq *PriorityQueue[A]) Peek() (A, bool) {
+	if len(pq.items) == 0 {
+		var zero A
+		return zero, false
+	}
+	return pq.items[0], true
+}
+
+// Pop removes and returns the smallest element in O(log n) time, or false if
+// the queue is empty.
+func (pq *PriorityQueue[A]) Pop() (A, bool) {
+	var zero A
+	var n = len(pq.items) - 1
+	if n < 0 {
+		return zero, false
+	}
+	var top = pq.items[0]
+	pq.items[0] = pq.items[n]
+	pq.items[n] = zero // release the reference for the garbage collector
+	pq.items = pq.items[:n]
+	pq.down(0)
+	return top, true
+}
+
+// All returns an iterator over the elements in an unspecified order, without
+// removing them.
+func (pq *PriorityQueue[A]) All() iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for _, a := range pq.items {
+			if !yield(a) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements from smallest to largest.
+// Elements that are not consumed remain in the queue.
+func (pq *PriorityQueue[A]) Drain() iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for len(pq.items) > 0 {
+			if a, _ := pq.Pop(); !yield(a) {
+				return
+			}
+		}
+	}
+}
+
+func (pq *PriorityQueue[A]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if pq.cmp(pq.items[i], pq.items[parent]) >= 0 {
+			return
+		}
+		pq.items[i], pq.items[parent] = pq.items[parent], pq.items[i]
+		i = parent
+	}
+}
+
+func (pq *PriorityQueue[A]) down(i int) {
+	var n = len(pq.items)
+	for {
+		least := i
+		for _, child := range [2]int{2*i + 1, 2*i + 2} {
+			if child < n && pq.cmp(pq.items[child], pq.items[least]) < 0 {
+				least = child
+			}
+		}
+		if least == i {
+			return
+		}
+		pq.items[i], pq.items[least] = pq.items[least], pq.items[i]
+		i = least
+	}
+}
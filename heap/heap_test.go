@@ -0,0 +1,68 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/basbiezemans/gofunctools/iters"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	xs := make([]int, 1000)
+	for i := range xs {
+		xs[i] = rng.IntN(100)
+	}
+	pq := New(cmp.Compare[int])
+	for _, x := range xs {
+		pq.Push(x)
+	}
+	if pq.Len() != len(xs) {
+		t.Errorf("Len() = %d, expected %d", pq.Len(), len(xs))
+	}
+	have := slices.Collect(pq.Drain())
+	if !slices.Equal(have, slices.Sorted(slices.Values(xs))) {
+		t.Errorf("Drain() is not sorted")
+	}
+	if _, ok := pq.Pop(); ok || pq.Len() != 0 {
+		t.Errorf("Pop() on drained queue = %v, expected false", ok)
+	}
+	if _, ok := pq.Peek(); ok {
+		t.Errorf("Peek() on empty queue = true, expected false")
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	xs := []int{5, 3, 9, 1, 7, 3}
+	maxFirst := func(a, b int) int { return cmp.Compare(b, a) }
+	pq := FromSeq(maxFirst, slices.Values(xs))
+	if top, ok := pq.Peek(); !ok || top != 9 {
+		t.Errorf("Peek() = %v, %v, expected 9, true", top, ok)
+	}
+	if have := slices.Sorted(pq.All()); !slices.Equal(have, slices.Sorted(slices.Values(xs))) {
+		t.Errorf("All() = %v, expected the elements %v", have, xs)
+	}
+	// Consuming part of Drain leaves the rest in the queue
+	top3 := slices.Collect(iters.Take(3, pq.Drain()))
+	if expect := []int{9, 7, 5}; !reflect.DeepEqual(top3, expect) || pq.Len() != 3 {
+		t.Errorf("top 3 = %v, %d left, expected %v, 3 left", top3, pq.Len(), expect)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	// Keep the k largest values in a min-heap of size k
+	k := 3
+	pq := New(cmp.Compare[int])
+	for _, x := range []int{4, 1, 8, 2, 9, 7, 3} {
+		pq.Push(x)
+		if pq.Len() > k {
+			pq.Pop()
+		}
+	}
+	if have := slices.Collect(pq.Drain()); !reflect.DeepEqual(have, []int{7, 8, 9}) {
+		t.Errorf("top 3 = %v, expected [7 8 9]", have)
+	}
+}
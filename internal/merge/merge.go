@@ -0,0 +1,42 @@
+// Package merge defines the heap of a stable k-way merge, which is shared by
+// the MergeSorted functions of packages slices and iters.
+package merge
+
+// An Item is the current value of a source in a k-way merge.
+type Item[A any] struct {
+	Value  A
+	Source int
+}
+
+// A Heap is a min-heap of merge items for container/heap. It breaks ties by
+// source, which keeps the merge stable.
+type Heap[A any] struct {
+	Items []Item[A]
+	Cmp   func(A, A) int
+}
+
+func (h *Heap[A]) Len() int {
+	return len(h.Items)
+}
+
+func (h *Heap[A]) Less(i, j int) bool {
+	if c := h.Cmp(h.Items[i].Value, h.Items[j].Value); c != 0 {
+		return c < 0
+	}
+	return h.Items[i].Source < h.Items[j].Source
+}
+
+func (h *Heap[A]) Swap(i, j int) {
+	h.Items[i], h.Items[j] = h.Items[j], h.Items[i]
+}
+
+func (h *Heap[A]) Push(x any) {
+	h.Items = append(h.Items, x.(Item[A]))
+}
+
+func (h *Heap[A]) Pop() any {
+	var n = len(h.Items)
+	var x = h.Items[n-1]
+	h.Items = h.Items[:n-1]
+	return x
+}
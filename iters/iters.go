@@ -2,9 +2,18 @@
 package iters
 
 import (
+	"context"
+	"errors"
 	"iter"
+
+	"github.com/basbiezemans/gofunctools/option"
+	"github.com/basbiezemans/gofunctools/trampoline"
 )
 
+// ErrLengthMismatch is reported by ZipStrict when the iterators have
+// different lengths.
+var ErrLengthMismatch = errors.New("iterators have different lengths")
+
 // Map applies a unary function to each element of an iterator.
 func Map[A, B any](fn func(A) B, seq iter.Seq[A]) iter.Seq[B] {
 	return func(yield func(B) bool) {
@@ -80,6 +89,69 @@ func ZipWith[A, B, C any](fn func(A, B) C, seq1 iter.Seq[A], seq2 iter.Seq[B]) i
 	}
 }
 
+// ZipLongest, applied to two iterators, pairs their elements as optional
+// values. If one iterator is shorter than the other, missing elements are
+// represented by None.
+func ZipLongest[A, B any](seq1 iter.Seq[A], seq2 iter.Seq[B]) iter.Seq2[option.Option[A], option.Option[B]] {
+	return func(yield func(option.Option[A], option.Option[B]) bool) {
+		next, stop := iter.Pull(seq2)
+		defer stop()
+		for v1 := range seq1 {
+			if !yield(option.Some(v1), option.New(next())) {
+				return
+			}
+		}
+		for v2, ok := next(); ok; v2, ok = next() {
+			if !yield(option.None[A](), option.Some(v2)) {
+				return
+			}
+		}
+	}
+}
+
+// ZipWithPad, applied to a combiner function and two iterators, combines their
+// elements using the combiner function. If one iterator is shorter than the
+// other, missing elements are replaced with padding values.
+func ZipWithPad[A, B, C any](fn func(A, B) C, a A, b B, seq1 iter.Seq[A], seq2 iter.Seq[B]) iter.Seq[C] {
+	return func(yield func(C) bool) {
+		for o1, o2 := range ZipLongest(seq1, seq2) {
+			if !yield(fn(o1.OrElse(a), o2.OrElse(b))) {
+				return
+			}
+		}
+	}
+}
+
+// ZipWithZero, applied to a combiner function and two iterators, combines
+// their elements using the combiner function. If one iterator is shorter than
+// the other, missing elements are replaced with zero values.
+func ZipWithZero[A, B, C any](fn func(A, B) C, seq1 iter.Seq[A], seq2 iter.Seq[B]) iter.Seq[C] {
+	var a A
+	var b B
+	return ZipWithPad(fn, a, b, seq1, seq2)
+}
+
+// ZipStrict, applied to a combiner function and two iterators, combines their
+// elements using the combiner function. Each combined value is yielded with a
+// nil error. If the iterators have different lengths, ZipStrict yields a zero
+// value with ErrLengthMismatch as its final element.
+func ZipStrict[A, B, C any](fn func(A, B) C, seq1 iter.Seq[A], seq2 iter.Seq[B]) iter.Seq2[C, error] {
+	return func(yield func(C, error) bool) {
+		var zero C
+		for o1, o2 := range ZipLongest(seq1, seq2) {
+			v1, ok1 := o1.Get()
+			v2, ok2 := o2.Get()
+			if !ok1 || !ok2 {
+				yield(zero, ErrLengthMismatch)
+				return
+			}
+			if !yield(fn(v1, v2), nil) {
+				return
+			}
+		}
+	}
+}
+
 // UnzipWith, applied to a splitter function and an iterator, splits elements
 // into two parts using the splitter function.
 func UnzipWith[A, B, C any](fn func(A) (B, C), seq iter.Seq[A]) iter.Seq2[B, C] {
@@ -106,6 +178,107 @@ func Unfold[A, B any](fn func(B) (A, B, bool), initValue B) iter.Seq[A] {
 	}
 }
 
+// UnfoldE is like Unfold, but its build function can fail. Each element is
+// yielded with a nil error. If the build function returns an error, UnfoldE
+// yields a zero value with that error as its final element.
+func UnfoldE[A, B any](fn func(B) (A, B, bool, error), initValue B) iter.Seq2[A, error] {
+	return func(yield func(A, error) bool) {
+		var v, next, ok, err = fn(initValue)
+		for ok && err == nil {
+			if !yield(v, nil) {
+				return
+			}
+			v, next, ok, err = fn(next)
+		}
+		if err != nil {
+			var zero A
+			yield(zero, err)
+		}
+	}
+}
+
+// UnfoldCtx is like UnfoldE, but it stops producing elements as soon as the
+// context is cancelled. The context's error is yielded as the final element.
+func UnfoldCtx[A, B any](ctx context.Context, fn func(B) (A, B, bool, error), initValue B) iter.Seq2[A, error] {
+	return UnfoldE(func(b B) (A, B, bool, error) {
+		if err := ctx.Err(); err != nil {
+			var zero A
+			return zero, b, false, err
+		}
+		return fn(b)
+	}, initValue)
+}
+
+// UnfoldN is like Unfold, but it produces at most n elements. The build
+// function is called no more than n times, which makes it a safety cap for
+// build functions that might not terminate.
+func UnfoldN[A, B any](n int, fn func(B) (A, B, bool), initValue B) iter.Seq[A] {
+	return Take(n, Unfold(fn, initValue))
+}
+
+// Paginate, applied to a page fetcher and an initial cursor, turns a
+// cursor-based API into a flat iterator of items. The fetcher returns a page
+// of items, the cursor of the next page and whether there are more pages.
+// Fetching stops at the first error or when the context is cancelled, in
+// which case the error is yielded as the final element.
+func Paginate[A, C any](ctx context.Context, fetch func(context.Context, C) ([]A, C, bool, error), cursor C) iter.Seq2[A, error] {
+	type state struct {
+		cursor C
+		done   bool
+	}
+	fetchPage := func(s state) ([]A, state, bool, error) {
+		if s.done {
+			return nil, s, false, nil
+		}
+		page, next, more, err := fetch(ctx, s.cursor)
+		return page, state{next, !more}, true, err
+	}
+	return func(yield func(A, error) bool) {
+		for page, err := range UnfoldCtx(ctx, fetchPage, state{cursor, false}) {
+			if err != nil {
+				var zero A
+				yield(zero, err)
+				return
+			}
+			for _, v := range page {
+				if !yield(v, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FoldRight, applied to a reducer function, an initialization value and an
+// iterator, reduces the iterator to a single value. This function "folds" an
+// iterator from right to left, starting with the initialization value. It
+// runs in constant stack space, regardless of the length of the iterator.
+func FoldRight[A, B any](fn func(A, B) B, initValue B, seq iter.Seq[A]) B {
+	return FoldRightT(func(x A, rest trampoline.Trampoline[B]) trampoline.Trampoline[B] {
+		return trampoline.Map(func(acc B) B {
+			return fn(x, acc)
+		}, rest)
+	}, initValue, seq)
+}
+
+// FoldRightT is a lazy variant of FoldRight. The reducer function receives
+// the fold of the remaining elements as a suspended computation, which it can
+// extend with trampoline.FlatMap or discard to stop early. Elements are only
+// pulled from the iterator when the remaining fold is evaluated.
+func FoldRightT[A, B any](fn func(A, trampoline.Trampoline[B]) trampoline.Trampoline[B], initValue B, seq iter.Seq[A]) B {
+	next, stop := iter.Pull(seq)
+	defer stop()
+	var fold func() trampoline.Trampoline[B]
+	fold = func() trampoline.Trampoline[B] {
+		v, ok := next()
+		if !ok {
+			return trampoline.Done(initValue)
+		}
+		return fn(v, trampoline.More(fold))
+	}
+	return trampoline.Run(trampoline.More(fold))
+}
+
 // Scan, applied to a reducer function and an iterator, produces an iterator
 // of successive reduced values.
 func Scan[A, B any](fn func(B, A) B, initValue B, seq iter.Seq[A]) iter.Seq[B] {
@@ -122,3 +295,215 @@ func Scan[A, B any](fn func(B, A) B, initValue B, seq iter.Seq[A]) iter.Seq[B] {
 		}
 	}
 }
+
+// Take, applied to a number n and an iterator, takes the first n elements of
+// the iterator. It never pulls more than n elements from the iterator.
+func Take[A any](n int, seq iter.Seq[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		if n <= 0 {
+			return
+		}
+		var i = 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			i += 1
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// Drop, applied to a number n and an iterator, drops the first n elements of
+// the iterator.
+func Drop[A any](n int, seq iter.Seq[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		var i = 0
+		for v := range seq {
+			if i < n {
+				i += 1
+				continue // drop element
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Nth, applied to an index and an iterator, returns the element at the given
+// (zero-based) index (a,true), or (zero,false) if there is no such element.
+func Nth[A any](n int, seq iter.Seq[A]) (A, bool) {
+	var zero A
+	if n < 0 {
+		return zero, false
+	}
+	var i = 0
+	for v := range seq {
+		if i == n {
+			return v, true
+		}
+		i += 1
+	}
+	return zero, false
+}
+
+// First returns the first element of an iterator (a,true), or (zero,false) if
+// the iterator is empty.
+func First[A any](seq iter.Seq[A]) (A, bool) {
+	return Nth(0, seq)
+}
+
+// Last returns the last element of an iterator (a,true), or (zero,false) if
+// the iterator is empty. The iterator has to be finite.
+func Last[A any](seq iter.Seq[A]) (A, bool) {
+	var last A
+	var ok = false
+	for v := range seq {
+		last, ok = v, true
+	}
+	return last, ok
+}
+
+// StepBy, applied to a step size and an iterator, yields the first element
+// and every step-th element after it. The step size must be positive.
+func StepBy[A any](step int, seq iter.Seq[A]) iter.Seq[A] {
+	if step <= 0 {
+		panic("step must be positive")
+	}
+	return func(yield func(A) bool) {
+		var i = 0
+		for v := range seq {
+			if i%step == 0 && !yield(v) {
+				return
+			}
+			i += 1
+		}
+	}
+}
+
+// Enumerate pairs each element of an iterator with its (zero-based) index.
+func Enumerate[A any](seq iter.Seq[A]) iter.Seq2[int, A] {
+	return func(yield func(int, A) bool) {
+		var i = 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i += 1
+		}
+	}
+}
+
+// Concat concatenates a number of iterators into a single iterator.
+func Concat[A any](seqs ...iter.Seq[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten concatenates an iterator of iterators into a single iterator.
+func Flatten[A any](seqs iter.Seq[iter.Seq[A]]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMap applies a function, returning an iterator, to each element of an
+// iterator and concatenates the results. It is the lazy counterpart of
+// slices.ConcatMap.
+func FlatMap[A, B any](fn func(A) iter.Seq[B], seq iter.Seq[A]) iter.Seq[B] {
+	return Flatten(Map(fn, seq))
+}
+
+// Interleave, applied to two iterators, alternates their elements, starting
+// with the first iterator. It stops as soon as either iterator is exhausted.
+func Interleave[A any](seq1, seq2 iter.Seq[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		next, stop := iter.Pull(seq2)
+		defer stop()
+		for v1 := range seq1 {
+			if !yield(v1) {
+				return
+			}
+			v2, ok := next()
+			if !ok || !yield(v2) {
+				return
+			}
+		}
+	}
+}
+
+// Intersperse, applied to a separator and an iterator, intersperses the
+// separator between the elements of the iterator.
+func Intersperse[A any](sep A, seq iter.Seq[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		var first = true
+		for v := range seq {
+			if !first && !yield(sep) {
+				return
+			}
+			first = false
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// RoundRobin takes one element from each iterator in turn, skipping iterators
+// that are exhausted, until all iterators are exhausted.
+func RoundRobin[A any](seqs ...iter.Seq[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		var nexts = make([]func() (A, bool), len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+		}
+		for len(nexts) > 0 {
+			var active = nexts[:0]
+			for _, next := range nexts {
+				v, ok := next()
+				if !ok {
+					continue // exhausted
+				}
+				if !yield(v) {
+					return
+				}
+				active = append(active, next)
+			}
+			nexts = active
+		}
+	}
+}
+
+// Product, applied to a combiner function and two iterators, combines each
+// element of the first iterator with each element of the second iterator
+// (cartesian product). The second iterator is traversed once for every
+// element of the first iterator, so it has to be re-iterable.
+func Product[A, B, C any](fn func(A, B) C, seq1 iter.Seq[A], seq2 iter.Seq[B]) iter.Seq[C] {
+	return func(yield func(C) bool) {
+		for v1 := range seq1 {
+			for v2 := range seq2 {
+				if !yield(fn(v1, v2)) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package iters
 
 import (
+	"context"
 	"errors"
 	"iter"
 	"reflect"
@@ -8,6 +9,9 @@ import (
 	"slices"
 	"strings"
 	"testing"
+
+	"github.com/basbiezemans/gofunctools/option"
+	"github.com/basbiezemans/gofunctools/trampoline"
 )
 
 func TestMap(t *testing.T) {
@@ -89,6 +93,68 @@ func TestZipWith(t *testing.T) {
 	}
 }
 
+func TestZipLongest(t *testing.T) {
+	it1 := slices.Values([]int{1, 2, 3})
+	it2 := slices.Values([]string{"a"})
+	expect1 := []option.Option[int]{option.Some(1), option.Some(2), option.Some(3)}
+	expect2 := []option.Option[string]{option.Some("a"), option.None[string](), option.None[string]()}
+	result1, result2 := collect(ZipLongest(it1, it2))
+	if !reflect.DeepEqual(result1, expect1) || !reflect.DeepEqual(result2, expect2) {
+		t.Errorf("result = %v, %v, expected %v, %v", result1, result2, expect1, expect2)
+	}
+	expect1 = []option.Option[int]{option.Some(1), option.None[int]()}
+	expect2 = []option.Option[string]{option.Some("a"), option.Some("b")}
+	result1, result2 = collect(ZipLongest(slices.Values([]int{1}), slices.Values([]string{"a", "b"})))
+	if !reflect.DeepEqual(result1, expect1) || !reflect.DeepEqual(result2, expect2) {
+		t.Errorf("result = %v, %v, expected %v, %v", result1, result2, expect1, expect2)
+	}
+}
+
+func TestZipWithPad(t *testing.T) {
+	expect := []int{2, 4, 3, 4}
+	it1 := slices.Values([]int{1, 2})
+	it2 := slices.Values([]int{1, 2, 3, 4})
+	result := slices.Collect(ZipWithPad(add, 0, 0, it1, it2))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+	expect = []int{1, 4, 30, 40}
+	result = slices.Collect(ZipWithPad(multiply, 10, 10, it2, it1))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+}
+
+func TestZipWithZero(t *testing.T) {
+	expect := []int{2, 4, 3, 4}
+	it1 := slices.Values([]int{1, 2})
+	it2 := slices.Values([]int{1, 2, 3, 4})
+	result := slices.Collect(ZipWithZero(add, it1, it2))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+}
+
+func TestZipStrict(t *testing.T) {
+	it1 := slices.Values([]int{1, 2, 3})
+	it2 := slices.Values([]int{1, 2, 3})
+	result, errs := collect(ZipStrict(multiply, it1, it2))
+	if !reflect.DeepEqual(result, []int{1, 4, 9}) || !reflect.DeepEqual(errs, []error{nil, nil, nil}) {
+		t.Errorf("result = %v, %v, expected [1 4 9], [<nil> <nil> <nil>]", result, errs)
+	}
+	testcases := []map[string][]int{
+		{"nums": {1, 2}, "expect": {1, 4, 0}},
+		{"nums": {1, 2, 3, 4}, "expect": {1, 4, 9, 0}},
+	}
+	for _, tc := range testcases {
+		result, errs = collect(ZipStrict(multiply, it1, slices.Values(tc["nums"])))
+		n := len(errs) - 1
+		if !reflect.DeepEqual(result, tc["expect"]) || !errors.Is(errs[n], ErrLengthMismatch) {
+			t.Errorf("result = %v, %v, expected %v and %v", result, errs, tc["expect"], ErrLengthMismatch)
+		}
+	}
+}
+
 func TestUnzipWith(t *testing.T) {
 	type DataPoint struct {
 		date string
@@ -117,6 +183,151 @@ func TestUnfold(t *testing.T) {
 	}
 }
 
+func TestUnfoldE(t *testing.T) {
+	errNegative := errors.New("negative")
+	countdown := func(x int) (int, int, bool, error) {
+		if x < 0 {
+			return 0, x, false, errNegative
+		}
+		return x, x - 2, x > 0, nil
+	}
+	result, errs := collect(UnfoldE(countdown, 4))
+	if !reflect.DeepEqual(result, []int{4, 2}) || !reflect.DeepEqual(errs, []error{nil, nil}) {
+		t.Errorf("result = %v, %v, expected [4 2], [<nil> <nil>]", result, errs)
+	}
+	result, errs = collect(UnfoldE(countdown, 3))
+	if !reflect.DeepEqual(result, []int{3, 1, 0}) || !errors.Is(errs[2], errNegative) {
+		t.Errorf("result = %v, %v, expected [3 1 0], [<nil> <nil> %v]", result, errs, errNegative)
+	}
+}
+
+func TestUnfoldCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	naturals := func(x int) (int, int, bool, error) {
+		if x == 3 {
+			cancel()
+		}
+		return x, x + 1, true, nil
+	}
+	result, errs := collect(UnfoldCtx(ctx, naturals, 0))
+	n := len(errs) - 1
+	if !reflect.DeepEqual(result, []int{0, 1, 2, 3, 0}) || !errors.Is(errs[n], context.Canceled) {
+		t.Errorf("result = %v, %v, expected [0 1 2 3 0] and %v", result, errs, context.Canceled)
+	}
+}
+
+func TestUnfoldN(t *testing.T) {
+	calls := 0
+	naturals := func(x int) (int, int, bool) {
+		calls += 1
+		return x, x + 1, true
+	}
+	expect := []int{0, 1, 2, 3, 4}
+	result := slices.Collect(UnfoldN(5, naturals, 0))
+	if !reflect.DeepEqual(result, expect) || calls != 5 {
+		t.Errorf("result = %v, calls %d, expected %v, calls 5", result, calls, expect)
+	}
+	expect = []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	result = slices.Collect(UnfoldN(100, decrement, 10))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	pages := map[string][]string{
+		"":   {"a", "b"},
+		"p2": {"c"},
+		"p3": {"d", "e"},
+	}
+	cursors := map[string]string{"": "p2", "p2": "p3"}
+	fetched := 0
+	fetch := func(_ context.Context, cursor string) ([]string, string, bool, error) {
+		fetched += 1
+		page, ok := pages[cursor]
+		if !ok {
+			return nil, "", false, errors.New("unknown cursor")
+		}
+		next, more := cursors[cursor]
+		return page, next, more, nil
+	}
+	ctx := context.Background()
+	expect := []string{"a", "b", "c", "d", "e"}
+	result, _ := collect(Paginate(ctx, fetch, ""))
+	if !reflect.DeepEqual(result, expect) || fetched != 3 {
+		t.Errorf("result = %v, fetched %d, expected %v, fetched 3", result, fetched, expect)
+	}
+	fetched = 0
+	result = nil
+	for v := range Paginate(ctx, fetch, "") {
+		if result = append(result, v); len(result) == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(result, expect[:2]) || fetched != 1 {
+		t.Errorf("result = %v, fetched %d, expected %v, fetched 1", result, fetched, expect[:2])
+	}
+	_, errs := collect(Paginate(ctx, fetch, "bad"))
+	if len(errs) != 1 || errs[0] == nil {
+		t.Errorf("errors = %v, expected [unknown cursor]", errs)
+	}
+}
+
+func TestFoldRight(t *testing.T) {
+	type TestCase struct {
+		callb  func(int, int) int
+		init   int
+		input  []int
+		expect int
+	}
+	testcases := []TestCase{
+		{add, 0, []int{1, 2, 3, 4}, 10},
+		{add, 42, []int{}, 42},
+		{subtract, 100, []int{1, 2, 3, 4}, 98},
+	}
+	errorMsg := "FoldRight(%s, %v, %v) = %v, expected %v"
+	for _, test := range testcases {
+		result := FoldRight(test.callb, test.init, slices.Values(test.input))
+		if result != test.expect {
+			t.Errorf(errorMsg, funcName(test.callb), test.init, test.input, result, test.expect)
+		}
+	}
+	// A long iterator must not grow the stack
+	n := 1_000_000
+	numbers := Take(n, Unfold(func(x int) (int, int, bool) {
+		return x, x + 1, true
+	}, 1))
+	expect := n * (n + 1) / 2
+	result := FoldRight(add, 0, numbers)
+	if result != expect {
+		t.Errorf(errorMsg, "add", 0, "[1..n]", result, expect)
+	}
+}
+
+func TestFoldRightT(t *testing.T) {
+	pulled := 0
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled += 1
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	// Stop folding at the first element greater than 5
+	anyGreaterThan5 := func(x int, rest trampoline.Trampoline[bool]) trampoline.Trampoline[bool] {
+		if x > 5 {
+			return trampoline.Done(true)
+		}
+		return rest
+	}
+	result := FoldRightT(anyGreaterThan5, false, naturals)
+	if !result || pulled != 7 {
+		t.Errorf("FoldRightT(anyGreaterThan5, false, naturals) = %t, pulled %d, expected true, pulled 7", result, pulled)
+	}
+}
+
 func TestScan(t *testing.T) {
 	type TestCase struct {
 		callb  func(int, int) int
@@ -149,6 +360,203 @@ func TestScan(t *testing.T) {
 	}
 }
 
+func TestTake(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	input := []int{2, 0, -1, 6}
+	expect := [][]int{
+		{1, 2}, nil, nil, {1, 2, 3, 4},
+	}
+	for i, n := range input {
+		result := slices.Collect(Take(n, slices.Values(data)))
+		if !reflect.DeepEqual(result, expect[i]) {
+			t.Errorf("Take(%d, %v) = %v, expected %v", n, data, result, expect[i])
+		}
+	}
+	// Take must not pull more than n elements from the upstream iterator
+	pulled := 0
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled += 1
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	result := slices.Collect(Take(3, naturals))
+	if !reflect.DeepEqual(result, []int{0, 1, 2}) || pulled != 3 {
+		t.Errorf("Take(3, naturals) = %v, pulled %d, expected [0 1 2], pulled 3", result, pulled)
+	}
+}
+
+func TestDrop(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	input := []int{2, 0, -1, 6}
+	expect := [][]int{
+		{3, 4}, {1, 2, 3, 4}, {1, 2, 3, 4}, nil,
+	}
+	for i, n := range input {
+		result := slices.Collect(Drop(n, slices.Values(data)))
+		if !reflect.DeepEqual(result, expect[i]) {
+			t.Errorf("Drop(%d, %v) = %v, expected %v", n, data, result, expect[i])
+		}
+	}
+}
+
+func TestNth(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	type TestCase struct {
+		index  int
+		expect int
+		ok     bool
+	}
+	testcases := []TestCase{
+		{0, 1, true}, {3, 4, true}, {4, 0, false}, {-1, 0, false},
+	}
+	for _, test := range testcases {
+		result, ok := Nth(test.index, slices.Values(data))
+		if result != test.expect || ok != test.ok {
+			t.Errorf("Nth(%d, %v) = (%d, %t), expected (%d, %t)", test.index, data, result, ok, test.expect, test.ok)
+		}
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	if x, ok := First(slices.Values(data)); !ok || x != 1 {
+		t.Errorf("First(%v) = (%d, %t), expected (1, true)", data, x, ok)
+	}
+	if x, ok := Last(slices.Values(data)); !ok || x != 4 {
+		t.Errorf("Last(%v) = (%d, %t), expected (4, true)", data, x, ok)
+	}
+	if _, ok := First(slices.Values([]int{})); ok {
+		t.Errorf("First([]) = (_, true), expected (_, false)")
+	}
+	if _, ok := Last(slices.Values([]int{})); ok {
+		t.Errorf("Last([]) = (_, true), expected (_, false)")
+	}
+}
+
+func TestStepBy(t *testing.T) {
+	data := []int{0, 1, 2, 3, 4, 5, 6}
+	input := []int{1, 2, 3, 10}
+	expect := [][]int{
+		{0, 1, 2, 3, 4, 5, 6}, {0, 2, 4, 6}, {0, 3, 6}, {0},
+	}
+	for i, n := range input {
+		result := slices.Collect(StepBy(n, slices.Values(data)))
+		if !reflect.DeepEqual(result, expect[i]) {
+			t.Errorf("StepBy(%d, %v) = %v, expected %v", n, data, result, expect[i])
+		}
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	data := []string{"a", "b", "c"}
+	expect1 := []int{0, 1, 2}
+	expect2 := []string{"a", "b", "c"}
+	result1, result2 := collect(Enumerate(slices.Values(data)))
+	if !reflect.DeepEqual(result1, expect1) || !reflect.DeepEqual(result2, expect2) {
+		t.Errorf("result = %v, %v, expected %v, %v", result1, result2, expect1, expect2)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	expect := []int{1, 2, 3, 4, 5}
+	it1 := slices.Values([]int{1, 2})
+	it2 := slices.Values([]int{})
+	it3 := slices.Values([]int{3, 4, 5})
+	result := slices.Collect(Concat(it1, it2, it3))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+	result = slices.Collect(Take(3, Concat(it1, it2, it3)))
+	if !reflect.DeepEqual(result, expect[:3]) {
+		t.Errorf("result = %v, expected %v", result, expect[:3])
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	fn := func(i int) iter.Seq[int] {
+		return slices.Values([]int{-i, i})
+	}
+	expect := []int{-1, 1, -2, 2, -3, 3}
+	result := slices.Collect(FlatMap(fn, slices.Values([]int{1, 2, 3})))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+	// Stopping early must stop both the outer and the inner iterators
+	pulled := 0
+	counting := func(i int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			for _, v := range []int{-i, i} {
+				pulled += 1
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	result = slices.Collect(Take(3, FlatMap(counting, slices.Values([]int{1, 2, 3}))))
+	if !reflect.DeepEqual(result, expect[:3]) || pulled != 3 {
+		t.Errorf("result = %v, pulled %d, expected %v, pulled 3", result, pulled, expect[:3])
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	testcases := []map[string][]int{
+		{"nums1": {1, 3, 5}, "nums2": {2, 4, 6}, "expect": {1, 2, 3, 4, 5, 6}},
+		{"nums1": {1, 3, 5}, "nums2": {2}, "expect": {1, 2, 3}},
+		{"nums1": {1}, "nums2": {2, 4, 6}, "expect": {1, 2}},
+		{"nums1": {}, "nums2": {2, 4}, "expect": nil},
+	}
+	for _, tc := range testcases {
+		it1 := slices.Values(tc["nums1"])
+		it2 := slices.Values(tc["nums2"])
+		result := slices.Collect(Interleave(it1, it2))
+		if !reflect.DeepEqual(result, tc["expect"]) {
+			t.Errorf("result = %v, expected %v", result, tc["expect"])
+		}
+	}
+}
+
+func TestIntersperse(t *testing.T) {
+	input := []string{"a", "b", "c"}
+	expect := []string{"a", ",", "b", ",", "c"}
+	result := slices.Collect(Intersperse(",", slices.Values(input)))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+	result = slices.Collect(Intersperse(",", slices.Values([]string{})))
+	if result != nil {
+		t.Errorf("result = %v, expected []", result)
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	it1 := slices.Values([]int{1, 4, 6})
+	it2 := slices.Values([]int{2})
+	it3 := slices.Values([]int{3, 5})
+	expect := []int{1, 2, 3, 4, 5, 6}
+	result := slices.Collect(RoundRobin(it1, it2, it3))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+	result = slices.Collect(Take(4, RoundRobin(it1, it2, it3)))
+	if !reflect.DeepEqual(result, expect[:4]) {
+		t.Errorf("result = %v, expected %v", result, expect[:4])
+	}
+}
+
+func TestProduct(t *testing.T) {
+	it1 := slices.Values([]int{1, 2})
+	it2 := slices.Values([]int{10, 20, 30})
+	expect := []int{10, 20, 30, 20, 40, 60}
+	result := slices.Collect(Product(multiply, it1, it2))
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, expected %v", result, expect)
+	}
+}
+
 // Helper functions
 
 func even(x int) bool {
@@ -0,0 +1,40 @@
+package iters
+
+import (
+	"container/heap"
+	"iter"
+
+	"github.com/basbiezemans/gofunctools/internal/merge"
+)
+
+// MergeSorted merges iterators that are sorted in ascending order by a
+// comparison function into a single sorted iterator. It holds one element of
+// each iterator at a time, so the iterators may be infinite. Equal elements
+// keep the order of the iterators they come from.
+func MergeSorted[A any](cmp func(A, A) int, seqs ...iter.Seq[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		var h = &merge.Heap[A]{Cmp: cmp}
+		var nexts = make([]func() (A, bool), len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+			if x, ok := next(); ok {
+				h.Items = append(h.Items, merge.Item[A]{Value: x, Source: i})
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			top := &h.Items[0]
+			if !yield(top.Value) {
+				return
+			}
+			if x, ok := nexts[top.Source](); ok {
+				top.Value = x
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package iters
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func multiples(n int) func(func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := n; yield(i); i += n {
+		}
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	have := slices.Collect(MergeSorted(cmp.Compare[int], slices.Values([]int{1, 4, 9}), slices.Values([]int{}), slices.Values([]int{2, 4, 10})))
+	if expect := []int{1, 2, 4, 4, 9, 10}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("MergeSorted = %v, expected %v", have, expect)
+	}
+	// Merging infinite iterators
+	have = slices.Collect(Take(10, MergeSorted(cmp.Compare[int], multiples(3), multiples(5))))
+	if expect := []int{3, 5, 6, 9, 10, 12, 15, 15, 18, 20}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("Take(10, MergeSorted(3n, 5n)) = %v, expected %v", have, expect)
+	}
+	if have := slices.Collect(MergeSorted[int](cmp.Compare[int])); len(have) != 0 {
+		t.Errorf("MergeSorted() = %v, expected []", have)
+	}
+}
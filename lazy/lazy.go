@@ -0,0 +1,120 @@
+// Package lazy defines values that are computed on demand. The computation of
+// a lazy value runs at most once, the first time the value is forced, and the
+// result is shared by all later calls, also across goroutines.
+package lazy
+
+import (
+	"sync"
+
+	"github.com/basbiezemans/gofunctools/pair"
+)
+
+// A Lazy value is computed the first time it is forced. The zero Lazy is not
+// usable; create one with Of or Value.
+type Lazy[A any] struct {
+	get func() A
+}
+
+// A LazyE value is like a Lazy value whose computation can fail. Both the
+// value and the error are computed once.
+type LazyE[A any] struct {
+	get func() (A, error)
+}
+
+// Create a lazy value that is computed by fn. If fn panics, every call to
+// Force panics with the same value.
+func Of[A any](fn func() A) Lazy[A] {
+	return Lazy[A]{sync.OnceValue(fn)}
+}
+
+// Create a lazy value that has already been computed.
+func Value[A any](a A) Lazy[A] {
+	return Lazy[A]{func() A { return a }}
+}
+
+// Force the computation of a lazy value and return the result.
+func (l Lazy[A]) Force() A {
+	return l.get()
+}
+
+// Map applies a unary function to a lazy value. Neither the value nor the
+// function is evaluated until the result is forced.
+func Map[A, B any](fn func(A) B, l Lazy[A]) Lazy[B] {
+	return Of(func() B { return fn(l.Force()) })
+}
+
+// FlatMap applies a function, which returns a lazy value, to a lazy value.
+func FlatMap[A, B any](fn func(A) Lazy[B], l Lazy[A]) Lazy[B] {
+	return Of(func() B { return fn(l.Force()).Force() })
+}
+
+// Zip combines two lazy values into a lazy pair.
+func Zip[A, B any](la Lazy[A], lb Lazy[B]) Lazy[pair.Pair[A, B]] {
+	return Of(func() pair.Pair[A, B] { return pair.New(la.Force(), lb.Force()) })
+}
+
+// Lift a unary function to a function on lazy values, so it can be composed
+// with Compose or Pipe without forcing any value.
+func Lift[A, B any](fn func(A) B) func(Lazy[A]) Lazy[B] {
+	return func(l Lazy[A]) Lazy[B] {
+		return Map(fn, l)
+	}
+}
+
+// Create a lazy value that is computed by a function that can fail.
+func OfE[A any](fn func() (A, error)) LazyE[A] {
+	return LazyE[A]{sync.OnceValues(fn)}
+}
+
+// Force the computation of a lazy value and return the result or the error.
+func (l LazyE[A]) Force() (A, error) {
+	return l.get()
+}
+
+// MapE applies a unary function to a lazy value that can fail. The function
+// is not called if the computation fails.
+func MapE[A, B any](fn func(A) B, l LazyE[A]) LazyE[B] {
+	return OfE(func() (B, error) {
+		a, err := l.Force()
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return fn(a), nil
+	})
+}
+
+// FlatMapE applies a function, which returns a lazy value that can fail, to a
+// lazy value that can fail.
+func FlatMapE[A, B any](fn func(A) LazyE[B], l LazyE[A]) LazyE[B] {
+	return OfE(func() (B, error) {
+		a, err := l.Force()
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return fn(a).Force()
+	})
+}
+
+// ZipE combines two lazy values that can fail into a lazy pair. The second
+// value is not forced if the first one fails.
+func ZipE[A, B any](la LazyE[A], lb LazyE[B]) LazyE[pair.Pair[A, B]] {
+	return OfE(func() (pair.Pair[A, B], error) {
+		var zero pair.Pair[A, B]
+		a, err := la.Force()
+		if err != nil {
+			return zero, err
+		}
+		b, err := lb.Force()
+		if err != nil {
+			return zero, err
+		}
+		return pair.New(a, b), nil
+	})
+}
+
+// Convert a lazy value to a lazy value that cannot fail.
+func ToE[A any](l Lazy[A]) LazyE[A] {
+	return LazyE[A]{func() (A, error) { return l.Force(), nil }}
+}
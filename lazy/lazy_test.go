@@ -0,0 +1,112 @@
+package lazy
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	fts "github.com/basbiezemans/gofunctools"
+	"github.com/basbiezemans/gofunctools/pair"
+)
+
+func counter() (*atomic.Int32, func() int) {
+	var calls atomic.Int32
+	return &calls, func() int {
+		calls.Add(1)
+		return 21
+	}
+}
+
+func TestOnce(t *testing.T) {
+	calls, expensive := counter()
+	l := Of(expensive)
+	if calls.Load() != 0 {
+		t.Fatalf("Of evaluated its function eagerly")
+	}
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if have := l.Force(); have != 21 {
+				t.Errorf("Force() = %d, expected 21", have)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("function was called %d times, expected 1", n)
+	}
+}
+
+func TestMapFlatMapZip(t *testing.T) {
+	calls, expensive := counter()
+	double := Map(func(n int) int { return 2 * n }, Of(expensive))
+	str := FlatMap(func(n int) Lazy[string] { return Value(strconv.Itoa(n)) }, double)
+	zipped := Zip(double, str)
+	if calls.Load() != 0 {
+		t.Fatalf("Map, FlatMap or Zip evaluated eagerly")
+	}
+	if have := zipped.Force(); have != pair.New(42, "42") {
+		t.Errorf("Zip(double, str).Force() = %v, expected (42, \"42\")", have)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("function was called %d times, expected 1", n)
+	}
+}
+
+func TestLift(t *testing.T) {
+	calls, expensive := counter()
+	pipeline := fts.Compose(Lift(strconv.Itoa), Lift(func(n int) int { return n + 1 }))
+	result := pipeline(Of(expensive))
+	if calls.Load() != 0 {
+		t.Fatalf("composed pipeline evaluated eagerly")
+	}
+	if have := result.Force(); have != "22" {
+		t.Errorf("pipeline.Force() = %q, expected \"22\"", have)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	l := Of(func() int { panic("boom") })
+	for range 2 {
+		func() {
+			defer func() {
+				if r := recover(); r != "boom" {
+					t.Errorf("Force() recovered %v, expected boom", r)
+				}
+			}()
+			l.Force()
+		}()
+	}
+}
+
+func TestLazyE(t *testing.T) {
+	errBoom := errors.New("boom")
+	var calls atomic.Int32
+	parse := OfE(func() (int, error) {
+		calls.Add(1)
+		return strconv.Atoi("12")
+	})
+	failing := OfE(func() (int, error) { return 0, errBoom })
+	forced := false
+	second := OfE(func() (string, error) { forced = true; return "x", nil })
+	if have, err := MapE(func(n int) int { return n * 2 }, parse).Force(); err != nil || have != 24 {
+		t.Errorf("MapE(double, parse).Force() = %v, %v, expected 24, <nil>", have, err)
+	}
+	if _, err := ZipE(failing, second).Force(); !errors.Is(err, errBoom) || forced {
+		t.Errorf("ZipE(failing, second).Force() = %v, forced second = %v", err, forced)
+	}
+	next := func(n int) LazyE[string] { return ToE(Value(strconv.Itoa(n))) }
+	if have, err := FlatMapE(next, parse).Force(); err != nil || have != "12" {
+		t.Errorf("FlatMapE(next, parse).Force() = %q, %v, expected \"12\", <nil>", have, err)
+	}
+	if _, err := FlatMapE(next, failing).Force(); !errors.Is(err, errBoom) {
+		t.Errorf("FlatMapE(next, failing).Force() = %v, expected %v", err, errBoom)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("parse was called %d times, expected 1", n)
+	}
+}
@@ -0,0 +1,135 @@
+// Package nonempty defines a slice type that contains at least one element.
+// Functions that are non-total on slices, like ReduceLeft, are total on
+// non-empty slices.
+package nonempty
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/basbiezemans/gofunctools/option"
+	fsl "github.com/basbiezemans/gofunctools/slices"
+	"golang.org/x/exp/constraints"
+)
+
+// ErrEmpty is returned by FromSliceE if the slice is empty.
+var ErrEmpty = errors.New("empty slice")
+
+// A NonEmpty is a slice with at least one element. It is stored as a head and
+// a tail, so every value has a head, even the zero value, which holds a single
+// zero element.
+type NonEmpty[A any] struct {
+	head A
+	tail []A
+}
+
+// Create a new NonEmpty from a head and a tail.
+func New[A any](head A, tail ...A) NonEmpty[A] {
+	return NonEmpty[A]{head, slices.Clone(tail)}
+}
+
+// Convert a slice to a NonEmpty, or None if the slice is empty.
+func FromSlice[A any](xs []A) option.Option[NonEmpty[A]] {
+	if len(xs) == 0 {
+		return option.None[NonEmpty[A]]()
+	}
+	return option.Some(New(xs[0], xs[1:]...))
+}
+
+// Convert a slice to a NonEmpty, or return ErrEmpty if the slice is empty.
+func FromSliceE[A any](xs []A) (NonEmpty[A], error) {
+	if len(xs) == 0 {
+		return NonEmpty[A]{}, ErrEmpty
+	}
+	return New(xs[0], xs[1:]...), nil
+}
+
+// Map applies a unary function to each element of a NonEmpty.
+func Map[A, B any](fn func(A) B, ne NonEmpty[A]) NonEmpty[B] {
+	return NonEmpty[B]{fn(ne.head), fsl.Map(fn, ne.tail)}
+}
+
+// ReduceLeft, applied to a reducer function and a NonEmpty, reduces the
+// elements to a single value from left to right.
+func ReduceLeft[A any](fn func(A, A) A, ne NonEmpty[A]) A {
+	return fsl.FoldLeft(fn, ne.head, ne.tail)
+}
+
+// ReduceRight, applied to a reducer function and a NonEmpty, reduces the
+// elements to a single value from right to left.
+func ReduceRight[A any](fn func(A, A) A, ne NonEmpty[A]) A {
+	if len(ne.tail) == 0 {
+		return ne.head
+	}
+	return fn(ne.head, fsl.ReduceRight(fn, ne.tail))
+}
+
+// Min returns the smallest element of a NonEmpty.
+func Min[A constraints.Ordered](ne NonEmpty[A]) A {
+	return ReduceLeft(func(a, b A) A { return min(a, b) }, ne)
+}
+
+// Max returns the largest element of a NonEmpty.
+func Max[A constraints.Ordered](ne NonEmpty[A]) A {
+	return ReduceLeft(func(a, b A) A { return max(a, b) }, ne)
+}
+
+// MinBy returns the smallest element of a NonEmpty according to a comparator,
+// which returns a negative number if a < b, zero if a == b, and a positive
+// number if a > b. If there are several smallest elements, the first one is
+// returned.
+func MinBy[A any](cmp func(A, A) int, ne NonEmpty[A]) A {
+	return ReduceLeft(func(a, b A) A {
+		if cmp(b, a) < 0 {
+			return b
+		}
+		return a
+	}, ne)
+}
+
+// MaxBy returns the largest element of a NonEmpty according to a comparator.
+// If there are several largest elements, the first one is returned.
+func MaxBy[A any](cmp func(A, A) int, ne NonEmpty[A]) A {
+	return ReduceLeft(func(a, b A) A {
+		if cmp(b, a) > 0 {
+			return b
+		}
+		return a
+	}, ne)
+}
+
+// Extract the first element.
+func (ne NonEmpty[A]) Head() A {
+	return ne.head
+}
+
+// Extract the elements after the head, which may be empty.
+func (ne NonEmpty[A]) Tail() []A {
+	return slices.Clone(ne.tail)
+}
+
+// Extract the last element.
+func (ne NonEmpty[A]) Last() A {
+	if len(ne.tail) == 0 {
+		return ne.head
+	}
+	return ne.tail[len(ne.tail)-1]
+}
+
+// Return the number of elements, which is at least 1.
+func (ne NonEmpty[A]) Len() int {
+	return 1 + len(ne.tail)
+}
+
+// Convert a NonEmpty to a slice.
+func (ne NonEmpty[A]) ToSlice() []A {
+	var xs = make([]A, 0, ne.Len())
+	xs = append(xs, ne.head)
+	return append(xs, ne.tail...)
+}
+
+// Reduce folds the elements from the left, starting from the head, like
+// ReduceLeft. It never fails, because the list is non-empty.
+func (ne NonEmpty[A]) Reduce(fn func(A, A) A) A {
+	return ReduceLeft(fn, ne)
+}
@@ -0,0 +1,109 @@
+package nonempty
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	opr "github.com/basbiezemans/gofunctools/operators"
+)
+
+func TestNew(t *testing.T) {
+	ne := New(1, 2, 3)
+	if ne.Head() != 1 || ne.Last() != 3 || ne.Len() != 3 {
+		t.Errorf("New(1, 2, 3) = %v, expected head 1, last 3, length 3", ne.ToSlice())
+	}
+	if have := ne.Tail(); !reflect.DeepEqual(have, []int{2, 3}) {
+		t.Errorf("New(1, 2, 3).Tail() = %v, expected [2 3]", have)
+	}
+	tail := []int{2, 3}
+	ne = New(1, tail...)
+	tail[0] = 0 // the NonEmpty must not share memory with the tail
+	if ne.Tail()[0] != 2 {
+		t.Errorf("New shares memory with its tail")
+	}
+	single := New("a")
+	if single.Head() != "a" || single.Last() != "a" || len(single.Tail()) != 0 {
+		t.Errorf("New(\"a\") = %v, expected [a]", single.ToSlice())
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	// The zero value holds a single zero element, so all functions are total
+	var zero NonEmpty[int]
+	if zero.Head() != 0 || zero.Last() != 0 || zero.Len() != 1 || len(zero.Tail()) != 0 {
+		t.Errorf("NonEmpty{} = %v, expected [0]", zero.ToSlice())
+	}
+	if ReduceLeft(opr.Add, zero) != 0 || ReduceRight(opr.Add, zero) != 0 || zero.Reduce(opr.Add) != 0 {
+		t.Errorf("reducing NonEmpty{} did not return 0")
+	}
+	if Min(zero) != 0 || Max(zero) != 0 || MinBy(cmp.Compare[int], zero) != 0 || MaxBy(cmp.Compare[int], zero) != 0 {
+		t.Errorf("Min/Max of NonEmpty{} did not return 0")
+	}
+	ne, err := FromSliceE([]int{})
+	if !errors.Is(err, ErrEmpty) || ne.Head() != 0 {
+		t.Errorf("FromSliceE([]) = %v, %v, expected [0], %v", ne.ToSlice(), err, ErrEmpty)
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	xs := []int{4, 5}
+	ne, ok := FromSlice(xs).Get()
+	if !ok || !reflect.DeepEqual(ne.ToSlice(), xs) {
+		t.Errorf("FromSlice(%v) = %v, %t, expected %v, true", xs, ne.ToSlice(), ok, xs)
+	}
+	xs[0] = 0 // the NonEmpty must not share memory with the slice
+	if ne.Head() != 4 {
+		t.Errorf("FromSlice shares memory with its argument")
+	}
+	if FromSlice([]int{}).IsSome() {
+		t.Errorf("FromSlice([]) = Some, expected None")
+	}
+	if _, err := FromSliceE([]int{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("FromSliceE([]) = %v, expected %v", err, ErrEmpty)
+	}
+	if ne, err := FromSliceE([]int{1}); err != nil || ne.Head() != 1 {
+		t.Errorf("FromSliceE([1]) = %v, %v, expected [1], <nil>", ne.ToSlice(), err)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	ne := New(1, 2, 3, 4)
+	if have := ReduceLeft(opr.Subtract, ne); have != -8 {
+		t.Errorf("ReduceLeft(subtract, %v) = %d, expected -8", ne.ToSlice(), have)
+	}
+	if have := ReduceRight(opr.Subtract, ne); have != -2 {
+		t.Errorf("ReduceRight(subtract, %v) = %d, expected -2", ne.ToSlice(), have)
+	}
+	if have := New(7).Reduce(opr.Add); have != 7 {
+		t.Errorf("New(7).Reduce(add) = %d, expected 7", have)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	ne := New(3, 1, 4, 1, 5)
+	if have := Min(ne); have != 1 {
+		t.Errorf("Min(%v) = %d, expected 1", ne.ToSlice(), have)
+	}
+	if have := Max(ne); have != 5 {
+		t.Errorf("Max(%v) = %d, expected 5", ne.ToSlice(), have)
+	}
+	words := New("pear", "fig", "banana")
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	if have := MinBy(byLen, words); have != "fig" {
+		t.Errorf("MinBy(byLen, %v) = %q, expected \"fig\"", words.ToSlice(), have)
+	}
+	if have := MaxBy(byLen, words); have != "banana" {
+		t.Errorf("MaxBy(byLen, %v) = %q, expected \"banana\"", words.ToSlice(), have)
+	}
+}
+
+func TestMap(t *testing.T) {
+	ne := New("a", "b")
+	have := Map(strings.ToUpper, ne)
+	if !reflect.DeepEqual(have.ToSlice(), []string{"A", "B"}) {
+		t.Errorf("Map(ToUpper, %v) = %v, expected [A B]", ne.ToSlice(), have.ToSlice())
+	}
+}
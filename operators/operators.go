@@ -5,6 +5,7 @@ package operators
 import (
 	"errors"
 	"math"
+	"unsafe"
 
 	"golang.org/x/exp/constraints"
 )
@@ -13,6 +14,10 @@ type Number interface {
 	constraints.Integer | constraints.Float
 }
 
+// ErrOverflow is returned by the checked integer operators if the result does
+// not fit in the integer type.
+var ErrOverflow = errors.New("integer overflow")
+
 // Modulo (remainder)
 func Modulo[T constraints.Integer](x, y T) T {
 	return x % y
@@ -74,13 +79,28 @@ func OR(x, y bool) bool {
 	return x || y
 }
 
+// Logical NOT
+func Not(x bool) bool {
+	return !x
+}
+
+// Logical XOR
+func XOR(x, y bool) bool {
+	return x != y
+}
+
+// Logical implication: x implies y.
+func Implies(x, y bool) bool {
+	return !x || y
+}
+
 // Equality
-func Equal[T constraints.Ordered](x, y T) bool {
+func Equal[T comparable](x, y T) bool {
 	return x == y
 }
 
 // Difference
-func NotEqual[T constraints.Ordered](x, y T) bool {
+func NotEqual[T comparable](x, y T) bool {
 	return x != y
 }
 
@@ -103,3 +123,292 @@ func LessThanOrEqual[T constraints.Ordered](x, y T) bool {
 func GreaterThanOrEqual[T constraints.Ordered](x, y T) bool {
 	return x >= y
 }
+
+// Minimum of two values. If either value is a floating-point NaN, the result
+// is NaN.
+func Min[T constraints.Ordered](x, y T) T {
+	return min(x, y)
+}
+
+// Maximum of two values. If either value is a floating-point NaN, the result
+// is NaN.
+func Max[T constraints.Ordered](x, y T) T {
+	return max(x, y)
+}
+
+// Clamp x to the closed interval [lo, hi]. The result is undefined if lo is
+// greater than hi.
+func Clamp[T constraints.Ordered](lo, hi, x T) T {
+	return min(max(x, lo), hi)
+}
+
+// Determine if x lies within the closed interval [lo, hi].
+func Between[T constraints.Ordered](lo, hi, x T) bool {
+	return lo <= x && x <= hi
+}
+
+// Bitwise AND
+func BitAnd[T constraints.Integer](x, y T) T {
+	return x & y
+}
+
+// Bitwise OR
+func BitOr[T constraints.Integer](x, y T) T {
+	return x | y
+}
+
+// Bitwise XOR
+func BitXor[T constraints.Integer](x, y T) T {
+	return x ^ y
+}
+
+// Bit clear (AND NOT)
+func AndNot[T constraints.Integer](x, y T) T {
+	return x &^ y
+}
+
+// Left shift. A negative shift count causes a run-time panic.
+func ShiftLeft[T, U constraints.Integer](x T, n U) T {
+	return x << n
+}
+
+// Right shift. Signed integers are shifted arithmetically. A negative shift
+// count causes a run-time panic.
+func ShiftRight[T, U constraints.Integer](x T, n U) T {
+	return x >> n
+}
+
+// Negation. The negation of the minimum value of a signed integer type is
+// that value itself; NegChecked reports this as an overflow.
+func Negate[T constraints.Signed | constraints.Float](x T) T {
+	return -x
+}
+
+// Absolute value. The absolute value of the minimum value of a signed integer
+// type is that value itself; AbsChecked reports this as an overflow.
+func Abs[T constraints.Signed | constraints.Float](x T) T {
+	if x <= 0 {
+		// Subtracting from zero also turns -0.0 into +0.0
+		return 0 - x
+	}
+	return x
+}
+
+// Sign returns -1, 0 or +1 depending on whether x is negative, zero or
+// positive. The sign of NaN is 0.
+func Sign[T Number](x T) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
+
+// Floored integer division, which rounds the quotient towards negative
+// infinity. This is Haskell's div, whereas Go's / operator truncates towards
+// zero. Together with Mod it satisfies x == IntDiv(x, y)*y + Mod(x, y).
+func IntDiv[T constraints.Integer](x, y T) T {
+	q, _ := divMod(x, y)
+	return q
+}
+
+// Floored modulus, which has the sign of the divisor. This is Haskell's mod,
+// whereas Go's % operator (see Modulo) has the sign of the dividend.
+func Mod[T constraints.Integer](x, y T) T {
+	_, r := divMod(x, y)
+	return r
+}
+
+// Euclidean integer division. Together with EuclidMod it satisfies
+// x == EuclidDiv(x, y)*y + EuclidMod(x, y).
+func EuclidDiv[T constraints.Integer](x, y T) T {
+	q, _ := euclidDivMod(x, y)
+	return q
+}
+
+// Euclidean modulus, which is never negative, regardless of the signs of x
+// and y.
+func EuclidMod[T constraints.Integer](x, y T) T {
+	_, r := euclidDivMod(x, y)
+	return r
+}
+
+// Checked addition. Returns ErrOverflow if the sum does not fit in T.
+func AddChecked[T constraints.Integer](x, y T) (T, error) {
+	if addOverflows(x, y) {
+		return 0, ErrOverflow
+	}
+	return x + y, nil
+}
+
+// Checked subtraction. Returns ErrOverflow if the difference does not fit in T.
+func SubChecked[T constraints.Integer](x, y T) (T, error) {
+	if subOverflows(x, y) {
+		return 0, ErrOverflow
+	}
+	return x - y, nil
+}
+
+// Checked multiplication. Returns ErrOverflow if the product does not fit in T.
+func MulChecked[T constraints.Integer](x, y T) (T, error) {
+	if mulOverflows(x, y) {
+		return 0, ErrOverflow
+	}
+	return x * y, nil
+}
+
+// Checked negation. Returns ErrOverflow if the negation does not fit in T,
+// i.e. for the minimum value of a signed type and for any non-zero value of
+// an unsigned type.
+func NegChecked[T constraints.Integer](x T) (T, error) {
+	if negOverflows(x) {
+		return 0, ErrOverflow
+	}
+	return -x, nil
+}
+
+// Checked absolute value. Returns ErrOverflow for the minimum value of a
+// signed type.
+func AbsChecked[T constraints.Integer](x T) (T, error) {
+	if x >= 0 {
+		return x, nil
+	}
+	return NegChecked(x)
+}
+
+// Saturating addition. The sum is clamped to the range of T.
+func AddSat[T constraints.Integer](x, y T) T {
+	if addOverflows(x, y) {
+		if y > 0 {
+			return maxOf[T]()
+		}
+		return minOf[T]()
+	}
+	return x + y
+}
+
+// Saturating subtraction. The difference is clamped to the range of T.
+func SubSat[T constraints.Integer](x, y T) T {
+	if subOverflows(x, y) {
+		if y > 0 {
+			return minOf[T]()
+		}
+		return maxOf[T]()
+	}
+	return x - y
+}
+
+// Saturating multiplication. The product is clamped to the range of T.
+func MulSat[T constraints.Integer](x, y T) T {
+	if mulOverflows(x, y) {
+		if (x < 0) != (y < 0) {
+			return minOf[T]()
+		}
+		return maxOf[T]()
+	}
+	return x * y
+}
+
+// Wrapping addition. The sum wraps around modulo 2^n, where n is the bit size
+// of T. This is the behaviour of Go's + operator, made explicit.
+func AddWrap[T constraints.Integer](x, y T) T {
+	return x + y
+}
+
+// Wrapping subtraction. The difference wraps around modulo 2^n.
+func SubWrap[T constraints.Integer](x, y T) T {
+	return x - y
+}
+
+// Wrapping multiplication. The product wraps around modulo 2^n.
+func MulWrap[T constraints.Integer](x, y T) T {
+	return x * y
+}
+
+// Wrapping negation. The negation wraps around modulo 2^n, so the minimum
+// value of a signed type is its own negation.
+func NegWrap[T constraints.Integer](x T) T {
+	return -x
+}
+
+// Compute the floored quotient and modulus of x and y.
+func divMod[T constraints.Integer](x, y T) (T, T) {
+	q, r := x/y, x%y
+	if r != 0 && (r < 0) != (y < 0) {
+		q, r = q-1, r+y
+	}
+	return q, r
+}
+
+// Compute the Euclidean quotient and modulus of x and y.
+func euclidDivMod[T constraints.Integer](x, y T) (T, T) {
+	q, r := x/y, x%y
+	if r < 0 {
+		if y > 0 {
+			q, r = q-1, r+y
+		} else {
+			q, r = q+1, r-y
+		}
+	}
+	return q, r
+}
+
+// Determine whether x + y overflows.
+func addOverflows[T constraints.Integer](x, y T) bool {
+	var r = x + y
+	if isSigned[T]() {
+		return (x > 0 && y > 0 && r < 0) || (x < 0 && y < 0 && r >= 0)
+	}
+	return r < x
+}
+
+// Determine whether x - y overflows.
+func subOverflows[T constraints.Integer](x, y T) bool {
+	var r = x - y
+	if isSigned[T]() {
+		return (y > 0 && r > x) || (y < 0 && r < x)
+	}
+	return y > x
+}
+
+// Determine whether x * y overflows.
+func mulOverflows[T constraints.Integer](x, y T) bool {
+	if x == 0 || y == 0 {
+		return false
+	}
+	// The quotient test below cannot detect min * -1, where ^T(0) is -1
+	if isSigned[T]() && ((x == ^T(0) && y == minOf[T]()) || (y == ^T(0) && x == minOf[T]())) {
+		return true
+	}
+	return (x*y)/y != x
+}
+
+// Determine whether -x overflows.
+func negOverflows[T constraints.Integer](x T) bool {
+	if isSigned[T]() {
+		return x == minOf[T]()
+	}
+	return x != 0
+}
+
+// Determine whether T is a signed integer type.
+func isSigned[T constraints.Integer]() bool {
+	var zero T
+	return ^zero < 0
+}
+
+// The minimum value of an integer type.
+func minOf[T constraints.Integer]() T {
+	if isSigned[T]() {
+		var zero T
+		return T(1) << (unsafe.Sizeof(zero)*8 - 1)
+	}
+	return 0
+}
+
+// The maximum value of an integer type.
+func maxOf[T constraints.Integer]() T {
+	return ^minOf[T]()
+}
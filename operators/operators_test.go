@@ -0,0 +1,297 @@
+package operators
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestCheckedInt8Exhaustive(t *testing.T) {
+	for x := math.MinInt8; x <= math.MaxInt8; x++ {
+		for y := math.MinInt8; y <= math.MaxInt8; y++ {
+			checkBinary(t, int8(x), int8(y))
+		}
+		checkUnary(t, int8(x))
+	}
+}
+
+func TestCheckedUint8Exhaustive(t *testing.T) {
+	for x := 0; x <= math.MaxUint8; x++ {
+		for y := 0; y <= math.MaxUint8; y++ {
+			checkBinary(t, uint8(x), uint8(y))
+		}
+		checkUnary(t, uint8(x))
+	}
+}
+
+func TestCheckedBoundaries(t *testing.T) {
+	testBoundaries[int](t)
+	testBoundaries[int16](t)
+	testBoundaries[int32](t)
+	testBoundaries[int64](t)
+	testBoundaries[uint](t)
+	testBoundaries[uint16](t)
+	testBoundaries[uint32](t)
+	testBoundaries[uint64](t)
+	testBoundaries[uintptr](t)
+	type myInt int32
+	testBoundaries[myInt](t)
+}
+
+func TestCheckedExamples(t *testing.T) {
+	if _, err := AddChecked[int64](math.MaxInt64, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("AddChecked(MaxInt64, 1) = %v, expected %v", err, ErrOverflow)
+	}
+	if have, err := MulChecked[int32](-46341, 46340); err != nil || have != -2147441940 {
+		t.Errorf("MulChecked(-46341, 46340) = %d, %v, expected -2147441940, <nil>", have, err)
+	}
+	if have := AddSat[uint8](200, 100); have != math.MaxUint8 {
+		t.Errorf("AddSat(200, 100) = %d, expected 255", have)
+	}
+	if have := SubSat[uint8](100, 200); have != 0 {
+		t.Errorf("SubSat(100, 200) = %d, expected 0", have)
+	}
+	if have := MulSat[int16](-300, 300); have != math.MinInt16 {
+		t.Errorf("MulSat(-300, 300) = %d, expected %d", have, math.MinInt16)
+	}
+	if have := AddWrap[int8](127, 1); have != -128 {
+		t.Errorf("AddWrap(127, 1) = %d, expected -128", have)
+	}
+	if have := NegWrap[int8](-128); have != -128 {
+		t.Errorf("NegWrap(-128) = %d, expected -128", have)
+	}
+	if _, err := AbsChecked[int8](-128); !errors.Is(err, ErrOverflow) {
+		t.Errorf("AbsChecked(-128) = %v, expected %v", err, ErrOverflow)
+	}
+	if have, err := AbsChecked[int8](-127); err != nil || have != 127 {
+		t.Errorf("AbsChecked(-127) = %d, %v, expected 127, <nil>", have, err)
+	}
+}
+
+// Test all pairs of values near the boundaries of an integer type.
+func testBoundaries[T constraints.Integer](t *testing.T) {
+	lo, hi := minOf[T](), maxOf[T]()
+	values := []T{lo, lo + 1, lo + 2, lo / 2, lo/2 - 1, 0, 1, 2, 3, hi / 2, hi/2 + 1, hi - 1, hi}
+	if isSigned[T]() {
+		values = append(values, ^T(0), ^T(1), ^T(2))
+	}
+	// Values near the square root of the maximum value
+	root := T(1) << (bitSize[T]() / 2)
+	values = append(values, root-1, root, root+1)
+	for _, x := range values {
+		for _, y := range values {
+			checkBinary(t, x, y)
+		}
+		checkUnary(t, x)
+	}
+}
+
+// Compare the checked, saturating and wrapping binary operators against
+// arbitrary-precision arithmetic.
+func checkBinary[T constraints.Integer](t *testing.T, x, y T) {
+	t.Helper()
+	bx, by := toBig(x), toBig(y)
+	ops := []struct {
+		name    string
+		checked func(T, T) (T, error)
+		sat     func(T, T) T
+		wrap    func(T, T) T
+		exact   func(z, x, y *big.Int) *big.Int
+	}{
+		{"Add", AddChecked[T], AddSat[T], AddWrap[T], (*big.Int).Add},
+		{"Sub", SubChecked[T], SubSat[T], SubWrap[T], (*big.Int).Sub},
+		{"Mul", MulChecked[T], MulSat[T], MulWrap[T], (*big.Int).Mul},
+	}
+	for _, op := range ops {
+		exact := op.exact(new(big.Int), bx, by)
+		fits := inRange[T](exact)
+		have, err := op.checked(x, y)
+		if fits && (err != nil || toBig(have).Cmp(exact) != 0) {
+			t.Errorf("%sChecked[%T](%d, %d) = %d, %v, expected %s", op.name, x, x, y, have, err, exact)
+		}
+		if !fits && !errors.Is(err, ErrOverflow) {
+			t.Errorf("%sChecked[%T](%d, %d) = %d, %v, expected %v", op.name, x, x, y, have, err, ErrOverflow)
+		}
+		if sat := toBig(op.sat(x, y)); sat.Cmp(clamp[T](exact)) != 0 {
+			t.Errorf("%sSat[%T](%d, %d) = %s, expected %s", op.name, x, x, y, sat, clamp[T](exact))
+		}
+		if wrap := toBig(op.wrap(x, y)); wrap.Cmp(wrapBig[T](exact)) != 0 {
+			t.Errorf("%sWrap[%T](%d, %d) = %s, expected %s", op.name, x, x, y, wrap, wrapBig[T](exact))
+		}
+	}
+}
+
+// Compare the checked unary operators against arbitrary-precision arithmetic.
+func checkUnary[T constraints.Integer](t *testing.T, x T) {
+	t.Helper()
+	neg := new(big.Int).Neg(toBig(x))
+	have, err := NegChecked(x)
+	if inRange[T](neg) != (err == nil) || (err == nil && toBig(have).Cmp(neg) != 0) {
+		t.Errorf("NegChecked[%T](%d) = %d, %v, expected %s", x, x, have, err, neg)
+	}
+	abs := new(big.Int).Abs(toBig(x))
+	have, err = AbsChecked(x)
+	if inRange[T](abs) != (err == nil) || (err == nil && toBig(have).Cmp(abs) != 0) {
+		t.Errorf("AbsChecked[%T](%d) = %d, %v, expected %s", x, x, have, err, abs)
+	}
+	if wrap := toBig(NegWrap(x)); wrap.Cmp(wrapBig[T](neg)) != 0 {
+		t.Errorf("NegWrap[%T](%d) = %s, expected %s", x, x, wrap, wrapBig[T](neg))
+	}
+}
+
+func bitSize[T constraints.Integer]() uint {
+	var n uint = 0
+	for x := ^T(0); x != 0; x <<= 1 {
+		n += 1
+	}
+	return n
+}
+
+func toBig[T constraints.Integer](x T) *big.Int {
+	if isSigned[T]() {
+		return big.NewInt(int64(x))
+	}
+	return new(big.Int).SetUint64(uint64(x))
+}
+
+func inRange[T constraints.Integer](z *big.Int) bool {
+	return z.Cmp(toBig(minOf[T]())) >= 0 && z.Cmp(toBig(maxOf[T]())) <= 0
+}
+
+func clamp[T constraints.Integer](z *big.Int) *big.Int {
+	lo, hi := toBig(minOf[T]()), toBig(maxOf[T]())
+	switch {
+	case z.Cmp(lo) < 0:
+		return lo
+	case z.Cmp(hi) > 0:
+		return hi
+	}
+	return z
+}
+
+// Reduce z modulo 2^n into the range of T.
+func wrapBig[T constraints.Integer](z *big.Int) *big.Int {
+	mod := new(big.Int).Lsh(big.NewInt(1), bitSize[T]())
+	r := new(big.Int).Mod(z, mod)
+	if isSigned[T]() && r.Cmp(toBig(maxOf[T]())) > 0 {
+		r.Sub(r, mod)
+	}
+	return r
+}
+
+func TestComparable(t *testing.T) {
+	type point struct{ x, y int }
+	p, q := &point{1, 2}, &point{1, 2}
+	if !Equal(*p, *q) || Equal(p, q) || !NotEqual(p, q) {
+		t.Errorf("Equal/NotEqual on structs and pointers gave wrong results")
+	}
+}
+
+func TestLogical(t *testing.T) {
+	for _, x := range []bool{false, true} {
+		for _, y := range []bool{false, true} {
+			if have := XOR(x, y); have != (x != y) {
+				t.Errorf("XOR(%v, %v) = %v", x, y, have)
+			}
+			if have := Implies(x, y); have == (x && !y) {
+				t.Errorf("Implies(%v, %v) = %v", x, y, have)
+			}
+		}
+		if Not(x) == x {
+			t.Errorf("Not(%v) = %v", x, x)
+		}
+	}
+}
+
+func TestBitwise(t *testing.T) {
+	x, y := uint8(0b1100), uint8(0b1010)
+	if BitAnd(x, y) != 0b1000 || BitOr(x, y) != 0b1110 || BitXor(x, y) != 0b0110 || AndNot(x, y) != 0b0100 {
+		t.Errorf("bitwise operators on %04b and %04b gave wrong results", x, y)
+	}
+	if have := ShiftLeft(x, 4); have != 0b11000000 {
+		t.Errorf("ShiftLeft(%b, 4) = %b", x, have)
+	}
+	if have := ShiftRight(int8(-8), uint(1)); have != -4 {
+		t.Errorf("ShiftRight(-8, 1) = %d, expected -4", have)
+	}
+}
+
+func TestUnary(t *testing.T) {
+	if Negate(3) != -3 || Abs(-3) != 3 || Abs(3.5) != 3.5 {
+		t.Errorf("Negate/Abs gave wrong results")
+	}
+	if have := Abs(math.Copysign(0, -1)); math.Signbit(have) {
+		t.Errorf("Abs(-0) = %v, expected +0", have)
+	}
+	if !math.IsNaN(Abs(math.NaN())) {
+		t.Errorf("Abs(NaN) is not NaN")
+	}
+	testcases := map[float64]int{-2.5: -1, 0: 0, 7: 1, math.Inf(-1): -1, math.NaN(): 0}
+	for x, expect := range testcases {
+		if have := Sign(x); have != expect {
+			t.Errorf("Sign(%v) = %d, expected %d", x, have, expect)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	if Min(3, 5) != 3 || Max("a", "b") != "b" {
+		t.Errorf("Min/Max gave wrong results")
+	}
+	testcases := map[int]int{-5: 0, 0: 0, 5: 5, 10: 10, 15: 10}
+	for x, expect := range testcases {
+		if have := Clamp(0, 10, x); have != expect {
+			t.Errorf("Clamp(0, 10, %d) = %d, expected %d", x, have, expect)
+		}
+		if have := Between(0, 10, x); have != (x == expect) {
+			t.Errorf("Between(0, 10, %d) = %v", x, have)
+		}
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	// Expected values from Haskell's div, mod and Euclidean division
+	testcases := []struct{ x, y, div, mod, ediv, emod int }{
+		{7, 2, 3, 1, 3, 1},
+		{-7, 2, -4, 1, -4, 1},
+		{7, -2, -4, -1, -3, 1},
+		{-7, -2, 3, -1, 4, 1},
+		{6, 3, 2, 0, 2, 0},
+		{-6, 3, -2, 0, -2, 0},
+		{0, -5, 0, 0, 0, 0},
+	}
+	for _, test := range testcases {
+		if have := IntDiv(test.x, test.y); have != test.div {
+			t.Errorf("IntDiv(%d, %d) = %d, expected %d", test.x, test.y, have, test.div)
+		}
+		if have := Mod(test.x, test.y); have != test.mod {
+			t.Errorf("Mod(%d, %d) = %d, expected %d", test.x, test.y, have, test.mod)
+		}
+		if have := EuclidDiv(test.x, test.y); have != test.ediv {
+			t.Errorf("EuclidDiv(%d, %d) = %d, expected %d", test.x, test.y, have, test.ediv)
+		}
+		if have := EuclidMod(test.x, test.y); have != test.emod {
+			t.Errorf("EuclidMod(%d, %d) = %d, expected %d", test.x, test.y, have, test.emod)
+		}
+	}
+	for x := math.MinInt8; x <= math.MaxInt8; x++ {
+		for y := math.MinInt8; y <= math.MaxInt8; y++ {
+			if y == 0 {
+				continue
+			}
+			a, b := int8(x), int8(y)
+			if IntDiv(a, b)*b+Mod(a, b) != a || EuclidDiv(a, b)*b+EuclidMod(a, b) != a {
+				t.Fatalf("division identity fails for %d and %d", a, b)
+			}
+			if m := EuclidMod(a, b); m < 0 {
+				t.Fatalf("EuclidMod(%d, %d) = %d, expected non-negative", a, b, m)
+			}
+		}
+	}
+	if IntDiv(uint8(7), 2) != 3 || Mod(uint8(7), 2) != 1 || EuclidMod(uint(7), 2) != 1 {
+		t.Errorf("unsigned division gave wrong results")
+	}
+}
@@ -0,0 +1,228 @@
+package operators
+
+import "golang.org/x/exp/constraints"
+
+// Operator sections fix one operand of a binary operator. A left section fixes
+// the left operand, e.g. SectionLeft(Subtract, 10) is x => 10 - x, and a right
+// section fixes the right operand, e.g. SectionRight(Subtract, 10) is
+// x => x - 10. The N-suffixed functions below are right sections of the
+// corresponding operators, so LessThanN(3) is the predicate x => x < 3.
+
+// Fix the left operand of a binary operator.
+func SectionLeft[A, B, C any](op func(A, B) C, x A) func(B) C {
+	return func(y B) C {
+		return op(x, y)
+	}
+}
+
+// Fix the right operand of a binary operator.
+func SectionRight[A, B, C any](op func(A, B) C, y B) func(A) C {
+	return func(x A) C {
+		return op(x, y)
+	}
+}
+
+// x => x + n
+func AddN[T Number | ~string](n T) func(T) T {
+	return SectionRight(Add[T], n)
+}
+
+// x => x - n
+func SubtractN[T Number](n T) func(T) T {
+	return SectionRight(Subtract[T], n)
+}
+
+// x => x * n
+func MultiplyN[T Number](n T) func(T) T {
+	return SectionRight(Multiply[T], n)
+}
+
+// x => x / n
+func DivideN[T Number](n T) func(T) T {
+	return SectionRight(Divide[T], n)
+}
+
+// x => x / n, or an error if n is zero
+func SafeDivN[T Number](n T) func(T) (T, error) {
+	return func(x T) (T, error) {
+		return SafeDiv(x, n)
+	}
+}
+
+// x => x % n
+func ModuloN[T constraints.Integer](n T) func(T) T {
+	return SectionRight(Modulo[T], n)
+}
+
+// x => x ** n
+func PowN[T Number](n T) func(T) float64 {
+	return SectionRight(Pow[T], n)
+}
+
+// x => IntDiv(x, n)
+func IntDivN[T constraints.Integer](n T) func(T) T {
+	return SectionRight(IntDiv[T], n)
+}
+
+// x => Mod(x, n)
+func ModN[T constraints.Integer](n T) func(T) T {
+	return SectionRight(Mod[T], n)
+}
+
+// x => EuclidDiv(x, n)
+func EuclidDivN[T constraints.Integer](n T) func(T) T {
+	return SectionRight(EuclidDiv[T], n)
+}
+
+// x => EuclidMod(x, n)
+func EuclidModN[T constraints.Integer](n T) func(T) T {
+	return SectionRight(EuclidMod[T], n)
+}
+
+// x => x % n == 0
+func DivisibleBy[T constraints.Integer](n T) func(T) bool {
+	return func(x T) bool {
+		return x%n == 0
+	}
+}
+
+// x => x && y
+func BoolAndN(y bool) func(bool) bool {
+	return SectionRight(AND, y)
+}
+
+// x => x || y
+func BoolOrN(y bool) func(bool) bool {
+	return SectionRight(OR, y)
+}
+
+// x => x != y
+func BoolXorN(y bool) func(bool) bool {
+	return SectionRight(XOR, y)
+}
+
+// x => x implies y
+func ImpliesN(y bool) func(bool) bool {
+	return SectionRight(Implies, y)
+}
+
+// x => x == y
+func EqualN[T comparable](y T) func(T) bool {
+	return SectionRight(Equal[T], y)
+}
+
+// x => x != y
+func NotEqualN[T comparable](y T) func(T) bool {
+	return SectionRight(NotEqual[T], y)
+}
+
+// x => x < y
+func LessThanN[T constraints.Ordered](y T) func(T) bool {
+	return SectionRight(LessThan[T], y)
+}
+
+// x => x > y
+func GreaterThanN[T constraints.Ordered](y T) func(T) bool {
+	return SectionRight(GreaterThan[T], y)
+}
+
+// x => x <= y
+func LessThanOrEqualN[T constraints.Ordered](y T) func(T) bool {
+	return SectionRight(LessThanOrEqual[T], y)
+}
+
+// x => x >= y
+func GreaterThanOrEqualN[T constraints.Ordered](y T) func(T) bool {
+	return SectionRight(GreaterThanOrEqual[T], y)
+}
+
+// x => min(x, y)
+func MinN[T constraints.Ordered](y T) func(T) T {
+	return SectionRight(Min[T], y)
+}
+
+// x => max(x, y)
+func MaxN[T constraints.Ordered](y T) func(T) T {
+	return SectionRight(Max[T], y)
+}
+
+// x => x & y
+func BitAndN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(BitAnd[T], y)
+}
+
+// x => x | y
+func BitOrN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(BitOr[T], y)
+}
+
+// x => x ^ y
+func BitXorN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(BitXor[T], y)
+}
+
+// x => x &^ y
+func AndNotN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(AndNot[T], y)
+}
+
+// x => x << n
+func ShiftLeftN[T, U constraints.Integer](n U) func(T) T {
+	return SectionRight(ShiftLeft[T, U], n)
+}
+
+// x => x >> n
+func ShiftRightN[T, U constraints.Integer](n U) func(T) T {
+	return SectionRight(ShiftRight[T, U], n)
+}
+
+// x => AddChecked(x, y)
+func AddCheckedN[T constraints.Integer](y T) func(T) (T, error) {
+	return func(x T) (T, error) {
+		return AddChecked(x, y)
+	}
+}
+
+// x => SubChecked(x, y)
+func SubCheckedN[T constraints.Integer](y T) func(T) (T, error) {
+	return func(x T) (T, error) {
+		return SubChecked(x, y)
+	}
+}
+
+// x => MulChecked(x, y)
+func MulCheckedN[T constraints.Integer](y T) func(T) (T, error) {
+	return func(x T) (T, error) {
+		return MulChecked(x, y)
+	}
+}
+
+// x => AddSat(x, y)
+func AddSatN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(AddSat[T], y)
+}
+
+// x => SubSat(x, y)
+func SubSatN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(SubSat[T], y)
+}
+
+// x => MulSat(x, y)
+func MulSatN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(MulSat[T], y)
+}
+
+// x => AddWrap(x, y)
+func AddWrapN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(AddWrap[T], y)
+}
+
+// x => SubWrap(x, y)
+func SubWrapN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(SubWrap[T], y)
+}
+
+// x => MulWrap(x, y)
+func MulWrapN[T constraints.Integer](y T) func(T) T {
+	return SectionRight(MulWrap[T], y)
+}
@@ -0,0 +1,100 @@
+package operators
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func filter[A any](p func(A) bool, xs []A) []A {
+	var ys []A
+	for _, x := range xs {
+		if p(x) {
+			ys = append(ys, x)
+		}
+	}
+	return ys
+}
+
+func TestSections(t *testing.T) {
+	if have := SectionLeft(Subtract[int], 10)(3); have != 7 {
+		t.Errorf("SectionLeft(Subtract, 10)(3) = %d, expected 7", have)
+	}
+	if have := SectionRight(Subtract[int], 10)(3); have != -7 {
+		t.Errorf("SectionRight(Subtract, 10)(3) = %d, expected -7", have)
+	}
+	if have := SectionLeft(Add[string], "pre")("fix"); have != "prefix" {
+		t.Errorf("SectionLeft(Add, \"pre\")(\"fix\") = %q, expected \"prefix\"", have)
+	}
+}
+
+func TestSectionPredicates(t *testing.T) {
+	data := []int{-4, 3, 9, 10, 11, 12, 15}
+	testcases := map[string]struct {
+		pred   func(int) bool
+		expect []int
+	}{
+		"x > 10":       {GreaterThanN(10), []int{11, 12, 15}},
+		"x < 3":        {LessThanN(3), []int{-4}},
+		"x <= 3":       {LessThanOrEqualN(3), []int{-4, 3}},
+		"x >= 12":      {GreaterThanOrEqualN(12), []int{12, 15}},
+		"x == 9":       {EqualN(9), []int{9}},
+		"x % 3 == 0":   {DivisibleBy(3), []int{3, 9, 12, 15}},
+		"x mod 3 == 2": {func(x int) bool { return ModN(3)(x) == 2 }, []int{-4, 11}},
+	}
+	for name, test := range testcases {
+		if have := filter(test.pred, data); !reflect.DeepEqual(have, test.expect) {
+			t.Errorf("%s: filter(%v) = %v, expected %v", name, data, have, test.expect)
+		}
+	}
+}
+
+func TestSectionOperators(t *testing.T) {
+	testcases := map[string]struct {
+		fn     func(int) int
+		input  int
+		expect int
+	}{
+		"AddN":        {AddN(2), 5, 7},
+		"SubtractN":   {SubtractN(2), 5, 3},
+		"MultiplyN":   {MultiplyN(2), 5, 10},
+		"DivideN":     {DivideN(2), 5, 2},
+		"ModuloN":     {ModuloN(3), -7, -1},
+		"IntDivN":     {IntDivN(2), -7, -4},
+		"EuclidDivN":  {EuclidDivN(-2), -7, 4},
+		"EuclidModN":  {EuclidModN(-2), -7, 1},
+		"MinN":        {MinN(3), 5, 3},
+		"MaxN":        {MaxN(3), 5, 5},
+		"BitAndN":     {BitAndN(6), 5, 4},
+		"BitOrN":      {BitOrN(6), 5, 7},
+		"BitXorN":     {BitXorN(6), 5, 3},
+		"AndNotN":     {AndNotN(6), 5, 1},
+		"ShiftLeftN":  {ShiftLeftN[int](2), 5, 20},
+		"ShiftRightN": {ShiftRightN[int](1), 5, 2},
+		"AddWrapN":    {AddWrapN(1), 5, 6},
+		"SubSatN":     {SubSatN(1), 5, 4},
+	}
+	for name, test := range testcases {
+		if have := test.fn(test.input); have != test.expect {
+			t.Errorf("%s(%d) = %d, expected %d", name, test.input, have, test.expect)
+		}
+	}
+	if have := AddSatN[uint8](10)(250); have != 255 {
+		t.Errorf("AddSatN(10)(250) = %d, expected 255", have)
+	}
+	if _, err := MulCheckedN[int8](2)(64); !errors.Is(err, ErrOverflow) {
+		t.Errorf("MulCheckedN(2)(64) = %v, expected %v", err, ErrOverflow)
+	}
+	if _, err := SafeDivN(0)(1); err == nil {
+		t.Errorf("SafeDivN(0)(1) did not return an error")
+	}
+	if have := PowN(2)(3); have != 9 {
+		t.Errorf("PowN(2)(3) = %v, expected 9", have)
+	}
+	if !ImpliesN(true)(false) || ImpliesN(false)(true) || !BoolXorN(true)(false) {
+		t.Errorf("ImpliesN/BoolXorN gave wrong results")
+	}
+	if BoolAndN(false)(true) || !BoolAndN(true)(true) || !BoolOrN(true)(false) || BoolOrN(false)(false) {
+		t.Errorf("BoolAndN/BoolOrN gave wrong results")
+	}
+}
@@ -0,0 +1,319 @@
+// Package optics defines lenses, prisms and traversals, which are composable
+// getters and setters for immutable updates of nested data structures.
+package optics
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/basbiezemans/gofunctools/option"
+	"github.com/basbiezemans/gofunctools/pair"
+)
+
+// A Lens focuses on exactly one part A of a whole S.
+type Lens[S, A any] struct {
+	get func(S) A
+	set func(A, S) S
+}
+
+// An Optional focuses on at most one part A of a whole S, e.g. a map entry,
+// a slice element or the target of a pointer.
+type Optional[S, A any] struct {
+	preview func(S) (A, bool)
+	set     func(A, S) S
+}
+
+// A Prism focuses on one case A of a sum type S. Unlike an Optional, a Prism
+// can build a whole from a part.
+type Prism[S, A any] struct {
+	preview func(S) (A, bool)
+	review  func(A) S
+}
+
+// A Traversal focuses on zero or more parts A of a whole S, e.g. all elements
+// of a slice or all values of a map.
+type Traversal[S, A any] struct {
+	getAll func(S) []A
+	modify func(func(A) A, S) S
+}
+
+// Create a new Lens from a getter and a setter. The setter must return an
+// updated copy of the whole and leave its argument unchanged.
+func NewLens[S, A any](get func(S) A, set func(A, S) S) Lens[S, A] {
+	return Lens[S, A]{get, set}
+}
+
+// Create a new Optional from a getter, which reports whether the part is
+// present, and a setter.
+func NewOptional[S, A any](preview func(S) (A, bool), set func(A, S) S) Optional[S, A] {
+	return Optional[S, A]{preview, set}
+}
+
+// Create a new Prism from a matcher and a constructor.
+func NewPrism[S, A any](preview func(S) (A, bool), review func(A) S) Prism[S, A] {
+	return Prism[S, A]{preview, review}
+}
+
+// Create a new Traversal from a function that collects all parts and a
+// function that updates all parts.
+func NewTraversal[S, A any](getAll func(S) []A, modify func(func(A) A, S) S) Traversal[S, A] {
+	return Traversal[S, A]{getAll, modify}
+}
+
+// Field creates a Lens for a struct field, given a function that returns a
+// pointer to the field. The function is applied to a copy of the struct.
+//
+//	name := optics.Field(func(p *Person) *string { return &p.Name })
+func Field[S, A any](fn func(*S) *A) Lens[S, A] {
+	return Lens[S, A]{
+		get: func(s S) A {
+			return *fn(&s)
+		},
+		set: func(a A, s S) S {
+			*fn(&s) = a
+			return s
+		},
+	}
+}
+
+// Fst is a Lens for the first component of a pair.
+func Fst[A, B any]() Lens[pair.Pair[A, B], A] {
+	return NewLens(pair.Pair[A, B].Fst, func(a A, p pair.Pair[A, B]) pair.Pair[A, B] {
+		return pair.New(a, p.Snd())
+	})
+}
+
+// Snd is a Lens for the second component of a pair.
+func Snd[A, B any]() Lens[pair.Pair[A, B], B] {
+	return NewLens(pair.Pair[A, B].Snd, func(b B, p pair.Pair[A, B]) pair.Pair[A, B] {
+		return pair.New(p.Fst(), b)
+	})
+}
+
+// Deref is an Optional for the target of a pointer, which is absent if the
+// pointer is nil. Setting the target allocates a new pointer.
+func Deref[A any]() Optional[*A, A] {
+	return NewOptional(func(p *A) (A, bool) {
+		var zero A
+		if p == nil {
+			return zero, false
+		}
+		return *p, true
+	}, func(a A, p *A) *A {
+		if p == nil {
+			return p
+		}
+		return &a
+	})
+}
+
+// Index is an Optional for the i-th element of a slice, which is absent if
+// the index is out of range. Setting the element copies the slice.
+func Index[A any](i int) Optional[[]A, A] {
+	return NewOptional(func(xs []A) (A, bool) {
+		var zero A
+		if i < 0 || i >= len(xs) {
+			return zero, false
+		}
+		return xs[i], true
+	}, func(a A, xs []A) []A {
+		if i < 0 || i >= len(xs) {
+			return xs
+		}
+		ys := slices.Clone(xs)
+		ys[i] = a
+		return ys
+	})
+}
+
+// Key is an Optional for the value of a map entry, which is absent if the
+// map has no such key. Setting the value copies the map.
+func Key[K comparable, V any](k K) Optional[map[K]V, V] {
+	return NewOptional(func(m map[K]V) (V, bool) {
+		v, ok := m[k]
+		return v, ok
+	}, func(v V, m map[K]V) map[K]V {
+		if _, ok := m[k]; !ok {
+			return m
+		}
+		n := maps.Clone(m)
+		n[k] = v
+		return n
+	})
+}
+
+// Each is a Traversal over all elements of a slice. Updating the elements
+// creates a new slice.
+func Each[A any]() Traversal[[]A, A] {
+	return NewTraversal(slices.Clone[[]A], func(fn func(A) A, xs []A) []A {
+		ys := make([]A, len(xs))
+		for i, x := range xs {
+			ys[i] = fn(x)
+		}
+		return ys
+	})
+}
+
+// Values is a Traversal over all values of a map. Updating the values creates
+// a new map. The order of the collected values is unspecified.
+func Values[K comparable, V any]() Traversal[map[K]V, V] {
+	return NewTraversal(func(m map[K]V) []V {
+		return slices.Collect(maps.Values(m))
+	}, func(fn func(V) V, m map[K]V) map[K]V {
+		n := make(map[K]V, len(m))
+		for k, v := range m {
+			n[k] = fn(v)
+		}
+		return n
+	})
+}
+
+// Compose combines two lenses into a lens that focuses on the part B of the
+// part A of a whole S.
+func Compose[S, A, B any](l1 Lens[S, A], l2 Lens[A, B]) Lens[S, B] {
+	return NewLens(func(s S) B {
+		return l2.get(l1.get(s))
+	}, func(b B, s S) S {
+		return l1.set(l2.set(b, l1.get(s)), s)
+	})
+}
+
+// ComposeOptional combines two optionals into an optional that focuses on the
+// part B of the part A of a whole S.
+func ComposeOptional[S, A, B any](o1 Optional[S, A], o2 Optional[A, B]) Optional[S, B] {
+	return NewOptional(func(s S) (B, bool) {
+		var zero B
+		a, ok := o1.preview(s)
+		if !ok {
+			return zero, false
+		}
+		return o2.preview(a)
+	}, func(b B, s S) S {
+		a, ok := o1.preview(s)
+		if !ok {
+			return s
+		}
+		return o1.set(o2.set(b, a), s)
+	})
+}
+
+// ComposeTraversal combines two traversals into a traversal that focuses on
+// all parts B of all parts A of a whole S.
+func ComposeTraversal[S, A, B any](t1 Traversal[S, A], t2 Traversal[A, B]) Traversal[S, B] {
+	return NewTraversal(func(s S) []B {
+		var bs []B
+		for _, a := range t1.getAll(s) {
+			bs = append(bs, t2.getAll(a)...)
+		}
+		return bs
+	}, func(fn func(B) B, s S) S {
+		return t1.modify(func(a A) A {
+			return t2.modify(fn, a)
+		}, s)
+	})
+}
+
+// Get the part from a whole.
+func (l Lens[S, A]) Get(s S) A {
+	return l.get(s)
+}
+
+// Set the part of a whole, returning an updated copy of the whole.
+func (l Lens[S, A]) Set(a A, s S) S {
+	return l.set(a, s)
+}
+
+// Modify the part of a whole with a function, returning an updated copy of
+// the whole.
+func (l Lens[S, A]) Modify(fn func(A) A, s S) S {
+	return l.set(fn(l.get(s)), s)
+}
+
+// Convert a Lens to an Optional whose part is always present.
+func (l Lens[S, A]) AsOptional() Optional[S, A] {
+	return NewOptional(func(s S) (A, bool) {
+		return l.get(s), true
+	}, l.set)
+}
+
+// Convert a Lens to a Traversal over exactly one part.
+func (l Lens[S, A]) AsTraversal() Traversal[S, A] {
+	return NewTraversal(func(s S) []A {
+		return []A{l.get(s)}
+	}, l.Modify)
+}
+
+// Get the part from a whole, if it is present.
+func (o Optional[S, A]) Preview(s S) option.Option[A] {
+	return option.New(o.preview(s))
+}
+
+// Set the part of a whole, if it is present. The whole is returned unchanged
+// if the part is absent.
+func (o Optional[S, A]) Set(a A, s S) S {
+	if _, ok := o.preview(s); !ok {
+		return s
+	}
+	return o.set(a, s)
+}
+
+// Modify the part of a whole with a function, if it is present.
+func (o Optional[S, A]) Modify(fn func(A) A, s S) S {
+	a, ok := o.preview(s)
+	if !ok {
+		return s
+	}
+	return o.set(fn(a), s)
+}
+
+// Convert an Optional to a Traversal over zero or one part.
+func (o Optional[S, A]) AsTraversal() Traversal[S, A] {
+	return NewTraversal(func(s S) []A {
+		if a, ok := o.preview(s); ok {
+			return []A{a}
+		}
+		return nil
+	}, o.Modify)
+}
+
+// Get the case from a sum type, if it matches.
+func (p Prism[S, A]) Preview(s S) option.Option[A] {
+	return option.New(p.preview(s))
+}
+
+// Build a sum type from a case.
+func (p Prism[S, A]) Review(a A) S {
+	return p.review(a)
+}
+
+// Modify the case of a sum type with a function, if it matches.
+func (p Prism[S, A]) Modify(fn func(A) A, s S) S {
+	a, ok := p.preview(s)
+	if !ok {
+		return s
+	}
+	return p.review(fn(a))
+}
+
+// Convert a Prism to an Optional.
+func (p Prism[S, A]) AsOptional() Optional[S, A] {
+	return NewOptional(p.preview, func(a A, _ S) S {
+		return p.review(a)
+	})
+}
+
+// Collect all parts of a whole.
+func (t Traversal[S, A]) GetAll(s S) []A {
+	return t.getAll(s)
+}
+
+// Modify all parts of a whole with a function, returning an updated copy of
+// the whole.
+func (t Traversal[S, A]) Modify(fn func(A) A, s S) S {
+	return t.modify(fn, s)
+}
+
+// Set all parts of a whole to the same value.
+func (t Traversal[S, A]) Set(a A, s S) S {
+	return t.modify(func(A) A { return a }, s)
+}
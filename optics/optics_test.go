@@ -0,0 +1,179 @@
+package optics
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	fts "github.com/basbiezemans/gofunctools"
+	opr "github.com/basbiezemans/gofunctools/operators"
+	"github.com/basbiezemans/gofunctools/option"
+	"github.com/basbiezemans/gofunctools/pair"
+)
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Person struct {
+	Name    string
+	Address Address
+	Manager *Person
+	Skills  map[string]int
+}
+
+var (
+	address = Field(func(p *Person) *Address { return &p.Address })
+	city    = Field(func(a *Address) *string { return &a.City })
+	name    = Field(func(p *Person) *string { return &p.Name })
+	manager = Field(func(p *Person) **Person { return &p.Manager })
+	skills  = Field(func(p *Person) *map[string]int { return &p.Skills })
+)
+
+func TestLens(t *testing.T) {
+	alice := Person{Name: "Alice", Address: Address{"Main St", "Utrecht"}}
+	if have := name.Get(alice); have != "Alice" {
+		t.Errorf("name.Get(%v) = %q, expected \"Alice\"", alice, have)
+	}
+	want := Person{Name: "Bob", Address: alice.Address}
+	have := name.Set("Bob", alice)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("name.Set(\"Bob\", %v) = %v, expected %v", alice, have, want)
+	}
+	if alice.Name != "Alice" {
+		t.Errorf("name.Set modified its argument: %v", alice)
+	}
+	want = Person{Name: "ALICE", Address: alice.Address}
+	have = name.Modify(strings.ToUpper, alice)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("name.Modify(ToUpper, %v) = %v, expected %v", alice, have, want)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	alice := Person{Name: "Alice", Address: Address{"Main St", "Utrecht"}}
+	personCity := Compose(address, city)
+	if have := personCity.Get(alice); have != "Utrecht" {
+		t.Errorf("personCity.Get(%v) = %q, expected \"Utrecht\"", alice, have)
+	}
+	want := Person{Name: "Alice", Address: Address{"Main St", "Amsterdam"}}
+	have := personCity.Set("Amsterdam", alice)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("personCity.Set(\"Amsterdam\", %v) = %v, expected %v", alice, have, want)
+	}
+	if alice.Address.City != "Utrecht" {
+		t.Errorf("personCity.Set modified its argument: %v", alice)
+	}
+}
+
+func TestPairLenses(t *testing.T) {
+	p := pair.New(1, "a")
+	add1 := fts.Partial1(opr.Add, 1)
+	if have := Fst[int, string]().Modify(add1, p); !reflect.DeepEqual(have, pair.New(2, "a")) {
+		t.Errorf("Fst().Modify(add1, %v) = %v, expected %v", p, have, pair.New(2, "a"))
+	}
+	if have := Snd[int, string]().Set("b", p); !reflect.DeepEqual(have, pair.New(1, "b")) {
+		t.Errorf("Snd().Set(\"b\", %v) = %v, expected %v", p, have, pair.New(1, "b"))
+	}
+}
+
+func TestOptional(t *testing.T) {
+	bob := Person{Name: "Bob"}
+	alice := Person{Name: "Alice", Manager: &bob}
+	managerName := ComposeOptional(ComposeOptional(manager.AsOptional(), Deref[Person]()), name.AsOptional())
+	if have := managerName.Preview(alice); !reflect.DeepEqual(have, option.Some("Bob")) {
+		t.Errorf("managerName.Preview(alice) = %v, expected Some(\"Bob\")", have)
+	}
+	if have := managerName.Preview(bob); have.IsSome() {
+		t.Errorf("managerName.Preview(bob) = %v, expected None", have)
+	}
+	updated := managerName.Set("Carol", alice)
+	if updated.Manager.Name != "Carol" || bob.Name != "Bob" {
+		t.Errorf("managerName.Set(\"Carol\", alice) = %v, original manager %v", updated.Manager, bob)
+	}
+	if have := managerName.Set("Carol", bob); !reflect.DeepEqual(have, bob) {
+		t.Errorf("managerName.Set(\"Carol\", bob) = %v, expected %v", have, bob)
+	}
+}
+
+func TestIndexKey(t *testing.T) {
+	xs := []int{1, 2, 3}
+	if have := Index[int](1).Set(20, xs); !reflect.DeepEqual(have, []int{1, 20, 3}) || xs[1] != 2 {
+		t.Errorf("Index(1).Set(20, %v) = %v, expected [1 20 3]", xs, have)
+	}
+	if have := Index[int](5).Set(20, xs); !reflect.DeepEqual(have, xs) {
+		t.Errorf("Index(5).Set(20, %v) = %v, expected %v", xs, have, xs)
+	}
+	alice := Person{Name: "Alice", Skills: map[string]int{"go": 3}}
+	goSkill := ComposeOptional(skills.AsOptional(), Key[string, int]("go"))
+	add1 := fts.Partial1(opr.Add, 1)
+	have := goSkill.Modify(add1, alice)
+	if have.Skills["go"] != 4 || alice.Skills["go"] != 3 {
+		t.Errorf("goSkill.Modify(add1, %v) = %v, expected go: 4", alice, have)
+	}
+	if have := Key[string, int]("rust").Preview(alice.Skills); have.IsSome() {
+		t.Errorf("Key(\"rust\").Preview(%v) = %v, expected None", alice.Skills, have)
+	}
+}
+
+type Shape interface{}
+
+type Circle struct{ Radius float64 }
+
+type Square struct{ Side float64 }
+
+func TestPrism(t *testing.T) {
+	circle := NewPrism(func(s Shape) (Circle, bool) {
+		c, ok := s.(Circle)
+		return c, ok
+	}, func(c Circle) Shape {
+		return c
+	})
+	double := func(c Circle) Circle { return Circle{2 * c.Radius} }
+	if have := circle.Modify(double, Circle{1}); have != (Circle{2}) {
+		t.Errorf("circle.Modify(double, Circle{1}) = %v, expected Circle{2}", have)
+	}
+	if have := circle.Modify(double, Square{1}); have != (Square{1}) {
+		t.Errorf("circle.Modify(double, Square{1}) = %v, expected Square{1}", have)
+	}
+	if have := circle.Review(Circle{3}); have != (Circle{3}) {
+		t.Errorf("circle.Review(Circle{3}) = %v, expected Circle{3}", have)
+	}
+	shapes := []Shape{Circle{1}, Square{1}, Circle{2}}
+	circles := ComposeTraversal(Each[Shape](), circle.AsOptional().AsTraversal())
+	want := []Shape{Circle{2}, Square{1}, Circle{4}}
+	if have := circles.Modify(double, shapes); !reflect.DeepEqual(have, want) {
+		t.Errorf("circles.Modify(double, %v) = %v, expected %v", shapes, have, want)
+	}
+	if have := circles.GetAll(shapes); !reflect.DeepEqual(have, []Circle{{1}, {2}}) {
+		t.Errorf("circles.GetAll(%v) = %v, expected [{1} {2}]", shapes, have)
+	}
+}
+
+func TestTraversal(t *testing.T) {
+	team := []Person{
+		{Name: "Alice", Address: Address{"Main St", "Utrecht"}},
+		{Name: "Bob", Address: Address{"High St", "Delft"}},
+	}
+	cities := ComposeTraversal(Each[Person](), Compose(address, city).AsTraversal())
+	if have := cities.GetAll(team); !reflect.DeepEqual(have, []string{"Utrecht", "Delft"}) {
+		t.Errorf("cities.GetAll(team) = %v, expected [Utrecht Delft]", have)
+	}
+	have := cities.Set("Leiden", team)
+	if have[0].Address.City != "Leiden" || have[1].Address.City != "Leiden" || team[0].Address.City != "Utrecht" {
+		t.Errorf("cities.Set(\"Leiden\", team) = %v", have)
+	}
+	scores := map[string]int{"a": 1, "b": 2}
+	double := fts.Partial1(opr.Multiply, 2)
+	want := map[string]int{"a": 2, "b": 4}
+	if have := Values[string, int]().Modify(double, scores); !reflect.DeepEqual(have, want) || scores["a"] != 1 {
+		t.Errorf("Values().Modify(double, %v) = %v, expected %v", scores, have, want)
+	}
+	values := Values[string, int]().GetAll(scores)
+	slices.Sort(values)
+	if !reflect.DeepEqual(values, []int{1, 2}) {
+		t.Errorf("Values().GetAll(%v) = %v, expected [1 2]", scores, values)
+	}
+}
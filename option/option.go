@@ -0,0 +1,66 @@
+// Package option defines an optional value type, which either holds a value
+// (Some) or holds nothing (None).
+package option
+
+type Option[A any] struct {
+	value A
+	ok    bool
+}
+
+// Create an Option that holds a value.
+func Some[A any](a A) Option[A] {
+	return Option[A]{a, true}
+}
+
+// Create an Option that holds nothing.
+func None[A any]() Option[A] {
+	return Option[A]{}
+}
+
+// Create an Option from a (value, ok) result, e.g. from a map lookup.
+func New[A any](a A, ok bool) Option[A] {
+	if !ok {
+		return None[A]()
+	}
+	return Some(a)
+}
+
+// Apply a function to the value of an Option, if there is one.
+func Map[A, B any](fn func(A) B, o Option[A]) Option[B] {
+	if !o.ok {
+		return None[B]()
+	}
+	return Some(fn(o.value))
+}
+
+// Apply a function, which returns an Option, to the value of an Option, if
+// there is one.
+func FlatMap[A, B any](fn func(A) Option[B], o Option[A]) Option[B] {
+	if !o.ok {
+		return None[B]()
+	}
+	return fn(o.value)
+}
+
+// Extract the value and a flag that reports whether there is one.
+func (o Option[A]) Get() (A, bool) {
+	return o.value, o.ok
+}
+
+// Report whether the Option holds a value.
+func (o Option[A]) IsSome() bool {
+	return o.ok
+}
+
+// Report whether the Option holds nothing.
+func (o Option[A]) IsNone() bool {
+	return !o.ok
+}
+
+// Extract the value or return a default value if there is none.
+func (o Option[A]) OrElse(defValue A) A {
+	if !o.ok {
+		return defValue
+	}
+	return o.value
+}
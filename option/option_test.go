@@ -0,0 +1,58 @@
+package option
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	if have := New(1, true); !reflect.DeepEqual(have, Some(1)) {
+		t.Errorf("New(1, true) = %v, expected %v", have, Some(1))
+	}
+	if have := New(1, false); !reflect.DeepEqual(have, None[int]()) {
+		t.Errorf("New(1, false) = %v, expected %v", have, None[int]())
+	}
+}
+
+func TestGet(t *testing.T) {
+	if v, ok := Some(1).Get(); !ok || v != 1 {
+		t.Errorf("Some(1).Get() = (%d, %t), expected (1, true)", v, ok)
+	}
+	if v, ok := None[int]().Get(); ok || v != 0 {
+		t.Errorf("None().Get() = (%d, %t), expected (0, false)", v, ok)
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	if have := Some(1).OrElse(2); have != 1 {
+		t.Errorf("Some(1).OrElse(2) = %d, expected 1", have)
+	}
+	if have := None[int]().OrElse(2); have != 2 {
+		t.Errorf("None().OrElse(2) = %d, expected 2", have)
+	}
+}
+
+func TestMap(t *testing.T) {
+	want := Some("1")
+	have := Map(strconv.Itoa, Some(1))
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("Map(Itoa, Some(1)) = %v, expected %v", have, want)
+	}
+	if have = Map(strconv.Itoa, None[int]()); have.IsSome() {
+		t.Errorf("Map(Itoa, None()) = %v, expected None", have)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	parse := func(s string) Option[int] {
+		n, err := strconv.Atoi(s)
+		return New(n, err == nil)
+	}
+	if have := FlatMap(parse, Some("12")); !reflect.DeepEqual(have, Some(12)) {
+		t.Errorf("FlatMap(parse, Some(\"12\")) = %v, expected Some(12)", have)
+	}
+	if have := FlatMap(parse, Some("x")); have.IsSome() {
+		t.Errorf("FlatMap(parse, Some(\"x\")) = %v, expected None", have)
+	}
+}
@@ -0,0 +1,367 @@
+// Package parse defines parser combinators. Small parsers for characters,
+// strings and regular expressions are combined into parsers for complete
+// languages with functions like Seq, Alt and Many.
+//
+// The parsers backtrack: Alt tries each alternative at the same position,
+// regardless of how much input a failed alternative consumed. If parsing
+// fails, the error reports the farthest position that any parser reached,
+// together with everything that was expected at that position.
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/basbiezemans/gofunctools/option"
+	"github.com/basbiezemans/gofunctools/pair"
+)
+
+// A Pos is a position in the input. Line and Column start at 1, and Column
+// counts runes, not bytes.
+type Pos struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+}
+
+// An Error describes why parsing failed.
+type Error struct {
+	Pos      Pos
+	Found    string   // the unexpected input, e.g. 'x' or end of input
+	Expected []string // what was expected at Pos, in order of the grammar
+}
+
+func (e *Error) Error() string {
+	var msg = e.Pos.String() + ": unexpected " + e.Found
+	if len(e.Expected) > 0 {
+		msg += ", expected " + orList(e.Expected)
+	}
+	return msg
+}
+
+// A Parser consumes a prefix of its input and produces a value of type A.
+type Parser[A any] struct {
+	run func(s *state, pos Pos) (A, Pos, bool)
+}
+
+// The parsing state records the farthest failure.
+type state struct {
+	input    string
+	failed   bool
+	errPos   Pos
+	expected []string
+}
+
+// Record that the given items were expected at pos.
+func (s *state) expect(pos Pos, items ...string) {
+	switch {
+	case !s.failed || pos.Offset > s.errPos.Offset:
+		s.failed, s.errPos, s.expected = true, pos, items
+	case pos.Offset == s.errPos.Offset:
+		s.expected = slices.Concat(s.expected, items)
+	}
+}
+
+func (s *state) error() *Error {
+	var found = "end of input"
+	if s.errPos.Offset < len(s.input) {
+		r, _ := utf8.DecodeRuneInString(s.input[s.errPos.Offset:])
+		found = strconv.QuoteRune(r)
+	}
+	var expected []string
+	for _, e := range s.expected {
+		if !slices.Contains(expected, e) {
+			expected = append(expected, e)
+		}
+	}
+	return &Error{s.errPos, found, expected}
+}
+
+// Run a parser on the start of the input and return the value and the
+// position after the consumed input. The input does not have to be consumed
+// completely.
+func Run[A any](p Parser[A], input string) (A, Pos, error) {
+	var s = &state{input: input}
+	a, pos, ok := p.run(s, Pos{0, 1, 1})
+	if !ok {
+		return a, pos, s.error()
+	}
+	return a, pos, nil
+}
+
+// Parse the complete input. It is an error if the parser does not consume
+// all input.
+func Parse[A any](p Parser[A], input string) (A, error) {
+	a, _, err := Run(Left(p, EOF()), input)
+	return a, err
+}
+
+// Advance a position past the text s.
+func advance(pos Pos, s string) Pos {
+	for _, r := range s {
+		if r == '\n' {
+			pos.Line, pos.Column = pos.Line+1, 1
+		} else {
+			pos.Column += 1
+		}
+	}
+	pos.Offset += len(s)
+	return pos
+}
+
+// Satisfy parses a single character that satisfies a predicate. It does not
+// describe what it expects; use Label for that.
+func Satisfy(fn func(rune) bool) Parser[rune] {
+	return Parser[rune]{func(s *state, pos Pos) (rune, Pos, bool) {
+		r, n := utf8.DecodeRuneInString(s.input[pos.Offset:])
+		if n == 0 || !fn(r) {
+			s.expect(pos)
+			return 0, pos, false
+		}
+		return r, advance(pos, s.input[pos.Offset:pos.Offset+n]), true
+	}}
+}
+
+// Char parses the character c.
+func Char(c rune) Parser[rune] {
+	return Label(strconv.QuoteRune(c), Satisfy(func(r rune) bool { return r == c }))
+}
+
+// String parses the string str.
+func String(str string) Parser[string] {
+	return Parser[string]{func(s *state, pos Pos) (string, Pos, bool) {
+		if !strings.HasPrefix(s.input[pos.Offset:], str) {
+			s.expect(pos, strconv.Quote(str))
+			return "", pos, false
+		}
+		return str, advance(pos, str), true
+	}}
+}
+
+// Regexp parses the longest text that matches a regular expression at the
+// current position. The match is anchored, so the expression does not need
+// to start with ^.
+func Regexp(re *regexp.Regexp) Parser[string] {
+	var anchored = regexp.MustCompile(`^(?:` + re.String() + `)`)
+	anchored.Longest()
+	var label = "/" + re.String() + "/"
+	return Parser[string]{func(s *state, pos Pos) (string, Pos, bool) {
+		var loc = anchored.FindStringIndex(s.input[pos.Offset:])
+		if loc == nil {
+			s.expect(pos, label)
+			return "", pos, false
+		}
+		var match = s.input[pos.Offset : pos.Offset+loc[1]]
+		return match, advance(pos, match), true
+	}}
+}
+
+// EOF succeeds only at the end of the input.
+func EOF() Parser[struct{}] {
+	return Parser[struct{}]{func(s *state, pos Pos) (struct{}, Pos, bool) {
+		if pos.Offset < len(s.input) {
+			s.expect(pos, "end of input")
+			return struct{}{}, pos, false
+		}
+		return struct{}{}, pos, true
+	}}
+}
+
+// Pure succeeds with the value a without consuming input.
+func Pure[A any](a A) Parser[A] {
+	return Parser[A]{func(s *state, pos Pos) (A, Pos, bool) {
+		return a, pos, true
+	}}
+}
+
+// Lazy defers the construction of a parser until it is used. It is needed
+// for recursive grammars, where a parser refers to itself.
+func Lazy[A any](fn func() Parser[A]) Parser[A] {
+	var get = sync.OnceValue(fn)
+	return Parser[A]{func(s *state, pos Pos) (A, Pos, bool) {
+		return get().run(s, pos)
+	}}
+}
+
+// Map applies a unary function to the value of a parser.
+func Map[A, B any](fn func(A) B, p Parser[A]) Parser[B] {
+	return Parser[B]{func(s *state, pos Pos) (B, Pos, bool) {
+		a, next, ok := p.run(s, pos)
+		if !ok {
+			var zero B
+			return zero, pos, false
+		}
+		return fn(a), next, true
+	}}
+}
+
+// FlatMap chooses the next parser based on the value of a parser.
+func FlatMap[A, B any](fn func(A) Parser[B], p Parser[A]) Parser[B] {
+	return Parser[B]{func(s *state, pos Pos) (B, Pos, bool) {
+		a, next, ok := p.run(s, pos)
+		if !ok {
+			var zero B
+			return zero, pos, false
+		}
+		return fn(a).run(s, next)
+	}}
+}
+
+// Map2 runs two parsers in sequence and combines their values.
+func Map2[A, B, C any](fn func(A, B) C, pa Parser[A], pb Parser[B]) Parser[C] {
+	return Parser[C]{func(s *state, pos Pos) (C, Pos, bool) {
+		var zero C
+		a, next, ok := pa.run(s, pos)
+		if !ok {
+			return zero, pos, false
+		}
+		b, next, ok := pb.run(s, next)
+		if !ok {
+			return zero, pos, false
+		}
+		return fn(a, b), next, true
+	}}
+}
+
+// Map3 runs three parsers in sequence and combines their values.
+func Map3[A, B, C, D any](fn func(A, B, C) D, pa Parser[A], pb Parser[B], pc Parser[C]) Parser[D] {
+	return Map2(func(ab pair.Pair[A, B], c C) D {
+		a, b := pair.Unpair(ab)
+		return fn(a, b, c)
+	}, Seq(pa, pb), pc)
+}
+
+// Seq runs two parsers in sequence and pairs their values.
+func Seq[A, B any](pa Parser[A], pb Parser[B]) Parser[pair.Pair[A, B]] {
+	return Map2(pair.New[A, B], pa, pb)
+}
+
+// Left runs two parsers in sequence and keeps the value of the first.
+func Left[A, B any](pa Parser[A], pb Parser[B]) Parser[A] {
+	return Map2(func(a A, _ B) A { return a }, pa, pb)
+}
+
+// Right runs two parsers in sequence and keeps the value of the second.
+func Right[A, B any](pa Parser[A], pb Parser[B]) Parser[B] {
+	return Map2(func(_ A, b B) B { return b }, pa, pb)
+}
+
+// Between parses open, p and close, and keeps the value of p.
+func Between[A, O, C any](open Parser[O], close Parser[C], p Parser[A]) Parser[A] {
+	return Left(Right(open, p), close)
+}
+
+// Alt tries the parsers in order and returns the value of the first one
+// that succeeds.
+func Alt[A any](ps ...Parser[A]) Parser[A] {
+	return Parser[A]{func(s *state, pos Pos) (A, Pos, bool) {
+		for _, p := range ps {
+			if a, next, ok := p.run(s, pos); ok {
+				return a, next, true
+			}
+		}
+		var zero A
+		return zero, pos, false
+	}}
+}
+
+// Many applies a parser zero or more times. It stops when the parser fails
+// or stops consuming input, so Many never loops forever.
+func Many[A any](p Parser[A]) Parser[[]A] {
+	return Parser[[]A]{func(s *state, pos Pos) ([]A, Pos, bool) {
+		var xs []A
+		for {
+			a, next, ok := p.run(s, pos)
+			if !ok || next.Offset == pos.Offset {
+				return xs, pos, true
+			}
+			xs, pos = append(xs, a), next
+		}
+	}}
+}
+
+// Many1 applies a parser one or more times.
+func Many1[A any](p Parser[A]) Parser[[]A] {
+	return Map2(prepend[A], p, Many(p))
+}
+
+// SepBy parses zero or more occurrences of p, separated by sep.
+func SepBy[A, S any](p Parser[A], sep Parser[S]) Parser[[]A] {
+	return Alt(SepBy1(p, sep), Pure[[]A](nil))
+}
+
+// SepBy1 parses one or more occurrences of p, separated by sep.
+func SepBy1[A, S any](p Parser[A], sep Parser[S]) Parser[[]A] {
+	return Map2(prepend[A], p, Many(Right(sep, p)))
+}
+
+// Chainl1 parses one or more occurrences of p, separated by op, and combines
+// the values with the functions returned by op from left to right. It is
+// typically used for left-associative binary operators.
+func Chainl1[A any](p Parser[A], op Parser[func(A, A) A]) Parser[A] {
+	type step = pair.Pair[func(A, A) A, A]
+	return Map2(func(x A, rest []step) A {
+		for _, st := range rest {
+			fn, y := pair.Unpair(st)
+			x = fn(x, y)
+		}
+		return x
+	}, p, Many(Seq(op, p)))
+}
+
+// Optional applies a parser, or succeeds with None without consuming input
+// if the parser fails.
+func Optional[A any](p Parser[A]) Parser[option.Option[A]] {
+	return Alt(Map(option.Some[A], p), Pure(option.None[A]()))
+}
+
+// Lookahead applies a parser without consuming input.
+func Lookahead[A any](p Parser[A]) Parser[A] {
+	return Parser[A]{func(s *state, pos Pos) (A, Pos, bool) {
+		a, _, ok := p.run(s, pos)
+		return a, pos, ok
+	}}
+}
+
+// Label names what a parser expects. If the parser fails at the position
+// where it started, the error reports the name instead of the expectations
+// of the parser itself. Failures beyond that position are reported as is.
+func Label[A any](name string, p Parser[A]) Parser[A] {
+	return Parser[A]{func(s *state, pos Pos) (A, Pos, bool) {
+		var failed, errPos, expected = s.failed, s.errPos, s.expected
+		s.failed, s.expected = false, nil
+		a, next, ok := p.run(s, pos)
+		if s.failed && s.errPos.Offset == pos.Offset {
+			s.expected = []string{name}
+		}
+		switch {
+		case !failed:
+		case !s.failed || errPos.Offset > s.errPos.Offset:
+			s.failed, s.errPos, s.expected = true, errPos, expected
+		case errPos.Offset == s.errPos.Offset:
+			s.expected = slices.Concat(expected, s.expected)
+		}
+		return a, next, ok
+	}}
+}
+
+func prepend[A any](x A, xs []A) []A {
+	return append([]A{x}, xs...)
+}
+
+// Join items as "a, b or c".
+func orList(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
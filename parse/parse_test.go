@@ -0,0 +1,191 @@
+package parse
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"unicode"
+
+	"github.com/basbiezemans/gofunctools/option"
+	"github.com/basbiezemans/gofunctools/pair"
+)
+
+// A JSON parser that produces the same values as encoding/json.
+
+var spaces = Many(Satisfy(unicode.IsSpace))
+
+func token[A any](p Parser[A]) Parser[A] {
+	return Left(p, spaces)
+}
+
+func symbol(c rune) Parser[rune] {
+	return token(Char(c))
+}
+
+var jsonNumber = Label("number", Map(func(s string) any {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}, token(Regexp(regexp.MustCompile(`-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?`)))))
+
+var jsonString = Label("string", token(Map(func(s string) string {
+	var str string
+	_ = json.Unmarshal([]byte(s), &str)
+	return str
+}, Regexp(regexp.MustCompile(`"([^"\\\x00-\x1f]|\\(["\\/bfnrt]|u[0-9a-fA-F]{4}))*"`)))))
+
+func literal(name string, value any) Parser[any] {
+	return Map(func(string) any { return value }, token(String(name)))
+}
+
+func jsonParser() Parser[any] {
+	var value Parser[any]
+	recurse := Lazy(func() Parser[any] { return value })
+	array := Map(func(xs []any) any {
+		if xs == nil {
+			return []any{}
+		}
+		return xs
+	}, Between(symbol('['), symbol(']'), SepBy(recurse, symbol(','))))
+	member := Seq(Left(jsonString, symbol(':')), recurse)
+	object := Map(func(kvs []pair.Pair[string, any]) any {
+		var obj = make(map[string]any)
+		for _, kv := range kvs {
+			k, v := pair.Unpair(kv)
+			obj[k] = v
+		}
+		return obj
+	}, Between(symbol('{'), symbol('}'), SepBy(member, symbol(','))))
+	value = Label("value", Alt(
+		literal("null", nil),
+		literal("true", true),
+		literal("false", false),
+		jsonNumber,
+		Map(func(s string) any { return s }, jsonString),
+		array,
+		object,
+	))
+	return Right(spaces, value)
+}
+
+var jsonDocument = jsonParser()
+
+func TestJSON(t *testing.T) {
+	inputs := []string{
+		`null`,
+		` [1, -2.5e3, true, false, null] `,
+		`{"name": "gofunctools", "tags": ["fp", "go"], "stars": 42,
+		  "nested": {"empty": {}, "list": [], "escaped": "a\"b\\cé\n"}}`,
+		`"ünïcödé"`,
+	}
+	for _, input := range inputs {
+		var expect any
+		if err := json.Unmarshal([]byte(input), &expect); err != nil {
+			t.Fatalf("json.Unmarshal(%q) = %v", input, err)
+		}
+		have, err := Parse(jsonDocument, input)
+		if err != nil || !reflect.DeepEqual(have, expect) {
+			t.Errorf("Parse(json, %q) = %v, %v, expected %v", input, have, err, expect)
+		}
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	testcases := map[string]string{
+		`[1, 2,, 3]`:                 `line 1, column 7: unexpected ',', expected value`,
+		"{\n  \"a\": 1\n  \"b\": 2}": `line 3, column 3: unexpected '"', expected ',' or '}'`,
+		`{"a" 1}`:                    `line 1, column 6: unexpected '1', expected ':'`,
+		`[1, 2`:                      `line 1, column 6: unexpected end of input, expected ',' or ']'`,
+		`tru`:                        `line 1, column 1: unexpected 't', expected value`,
+		`[01]`:                       `line 1, column 3: unexpected '1', expected ',' or ']'`,
+		`{} x`:                       `line 1, column 4: unexpected 'x', expected end of input`,
+	}
+	for input, expect := range testcases {
+		_, err := Parse(jsonDocument, input)
+		var perr *Error
+		if !errors.As(err, &perr) || err.Error() != expect {
+			t.Errorf("Parse(json, %q) = %v, expected %q", input, err, expect)
+		}
+	}
+}
+
+func TestPositions(t *testing.T) {
+	p := Right(String("ab\nc"), Satisfy(unicode.IsDigit))
+	r, pos, err := Run(p, "ab\ncé7x")
+	if err == nil {
+		t.Fatalf("Run(p, \"ab\\ncé7x\") = %q, expected an error", r)
+	}
+	if perr := err.(*Error); perr.Pos != (Pos{4, 2, 2}) || perr.Found != "'é'" {
+		t.Errorf("error at %v found %s, expected offset 4, line 2, column 2, found 'é'", perr.Pos, perr.Found)
+	}
+	r, pos, err = Run(Right(String("ab\ncé"), Satisfy(unicode.IsDigit)), "ab\ncé7x")
+	if err != nil || r != '7' || pos != (Pos{7, 2, 4}) {
+		t.Errorf("Run = %q, %v, %v, expected '7' at offset 7, line 2, column 4", r, pos, err)
+	}
+}
+
+func TestChainl1(t *testing.T) {
+	number := token(Map(func(s string) int { n, _ := strconv.Atoi(s); return n },
+		Regexp(regexp.MustCompile(`[0-9]+`))))
+	op := func(c rune, fn func(int, int) int) Parser[func(int, int) int] {
+		return Map(func(rune) func(int, int) int { return fn }, symbol(c))
+	}
+	var expr Parser[int]
+	factor := Alt(number, Between(symbol('('), symbol(')'), Lazy(func() Parser[int] { return expr })))
+	term := Chainl1(factor, Alt(op('*', func(x, y int) int { return x * y }), op('/', func(x, y int) int { return x / y })))
+	expr = Chainl1(term, Alt(op('+', func(x, y int) int { return x + y }), op('-', func(x, y int) int { return x - y })))
+	testcases := map[string]int{
+		"1":               1,
+		"10 - 3 - 2":      5,
+		"2 + 3 * 4":       14,
+		"(2 + 3) * 4":     20,
+		"100 / 10 / 5":    2,
+		"2 * (3 + (4-1))": 12,
+	}
+	for input, expect := range testcases {
+		if have, err := Parse(expr, input); err != nil || have != expect {
+			t.Errorf("Parse(expr, %q) = %v, %v, expected %d", input, have, err, expect)
+		}
+	}
+	_, err := Parse(expr, "2 * (3 + )")
+	if msg := "line 1, column 10: unexpected ')', expected /[0-9]+/ or '('"; err == nil || err.Error() != msg {
+		t.Errorf("Parse(expr, \"2 * (3 + )\") = %v, expected %q", err, msg)
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	digit := Label("digit", Satisfy(unicode.IsDigit))
+	sign := Optional(Char('-'))
+	if have, err := Parse(Seq(sign, Many1(digit)), "-12"); err != nil || !reflect.DeepEqual(have, pair.New(option.Some('-'), []rune("12"))) {
+		t.Errorf("Parse(sign digits, \"-12\") = %v, %v", have, err)
+	}
+	if have, _ := Parse(Seq(sign, Many1(digit)), "12"); !reflect.DeepEqual(have, pair.New(option.None[rune](), []rune("12"))) {
+		t.Errorf("Parse(sign digits, \"12\") = %v", have)
+	}
+	if _, err := Parse(Many1(digit), ""); err == nil || err.Error() != "line 1, column 1: unexpected end of input, expected digit" {
+		t.Errorf("Parse(Many1(digit), \"\") = %v", err)
+	}
+	peek, pos, err := Run(Lookahead(String("ab")), "abc")
+	if err != nil || peek != "ab" || pos.Offset != 0 {
+		t.Errorf("Run(Lookahead(\"ab\"), \"abc\") = %q, %v, %v, expected \"ab\" at offset 0", peek, pos, err)
+	}
+	keyword := FlatMap(func(s string) Parser[string] {
+		if s == "let" {
+			return Pure(s)
+		}
+		return Map(func(struct{}) string { return "" }, EOF())
+	}, Regexp(regexp.MustCompile(`[a-z]+`)))
+	if have, err := Parse(keyword, "let"); err != nil || have != "let" {
+		t.Errorf("Parse(keyword, \"let\") = %q, %v", have, err)
+	}
+	// Many stops at a parser that does not consume input
+	if have, err := Parse(Left(Many(Optional(Char('x'))), String("yy")), "xxyy"); err != nil || len(have) != 2 {
+		t.Errorf("Parse(Many(Optional(x)), \"xxyy\") = %v, %v", have, err)
+	}
+	csv := SepBy(Regexp(regexp.MustCompile(`[a-z]*`)), Char(','))
+	if have, err := Parse(csv, "a,,bc"); err != nil || !reflect.DeepEqual(have, []string{"a", "", "bc"}) {
+		t.Errorf("Parse(csv, \"a,,bc\") = %q, %v", have, err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package predicate defines combinators for predicates, i.e. functions of type
+// func(A) bool, as accepted by Filter, Any, All, Partition, etc.
+package predicate
+
+// Negate a predicate.
+func Not[A any](p func(A) bool) func(A) bool {
+	return func(a A) bool {
+		return !p(a)
+	}
+}
+
+// Combine two predicates with a logical AND. The second predicate is not
+// evaluated if the first one is false.
+func And[A any](p, q func(A) bool) func(A) bool {
+	return func(a A) bool {
+		return p(a) && q(a)
+	}
+}
+
+// Combine two predicates with a logical OR. The second predicate is not
+// evaluated if the first one is true.
+func Or[A any](p, q func(A) bool) func(A) bool {
+	return func(a A) bool {
+		return p(a) || q(a)
+	}
+}
+
+// Combine two predicates with a logical XOR.
+func Xor[A any](p, q func(A) bool) func(A) bool {
+	return func(a A) bool {
+		return p(a) != q(a)
+	}
+}
+
+// Create a predicate that holds if all given predicates hold. The predicates
+// are evaluated from left to right, until one of them is false. AllOf() is
+// always true.
+func AllOf[A any](preds ...func(A) bool) func(A) bool {
+	return func(a A) bool {
+		for _, p := range preds {
+			if !p(a) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Create a predicate that holds if any of the given predicates holds. The
+// predicates are evaluated from left to right, until one of them is true.
+// AnyOf() is always false.
+func AnyOf[A any](preds ...func(A) bool) func(A) bool {
+	return func(a A) bool {
+		for _, p := range preds {
+			if p(a) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Create a predicate that holds if none of the given predicates holds.
+func NoneOf[A any](preds ...func(A) bool) func(A) bool {
+	return Not(AnyOf(preds...))
+}
+
+// Create a predicate that holds for values equal to x.
+func Equals[A comparable](x A) func(A) bool {
+	return func(a A) bool {
+		return a == x
+	}
+}
+
+// Create a predicate that holds for values that are members of a set. The
+// set is copied, so later changes to xs do not affect the predicate.
+func In[A comparable](xs ...A) func(A) bool {
+	var set = make(map[A]struct{}, len(xs))
+	for _, x := range xs {
+		set[x] = struct{}{}
+	}
+	return func(a A) bool {
+		_, ok := set[a]
+		return ok
+	}
+}
+
+// Apply a predicate to a key of a value, e.g. a struct field.
+func On[A, K any](key func(A) K, p func(K) bool) func(A) bool {
+	return func(a A) bool {
+		return p(key(a))
+	}
+}
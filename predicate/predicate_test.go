@@ -0,0 +1,76 @@
+package predicate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	fn "github.com/basbiezemans/gofunctools"
+	opr "github.com/basbiezemans/gofunctools/operators"
+	fsl "github.com/basbiezemans/gofunctools/slices"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestCombinators(t *testing.T) {
+	even, positive := opr.Even[int], fn.Partial1(opr.LessThan[int], 0)
+	data := []int{-3, -2, -1, 0, 1, 2, 3, 4}
+	testcases := map[string]struct {
+		pred   func(int) bool
+		expect []int
+	}{
+		"Not":    {Not(even), []int{-3, -1, 1, 3}},
+		"And":    {And(even, positive), []int{2, 4}},
+		"Or":     {Or(even, positive), []int{-2, 0, 1, 2, 3, 4}},
+		"Xor":    {Xor(even, positive), []int{-2, 0, 1, 3}},
+		"AllOf":  {AllOf(even, positive, fn.Partial1(opr.GreaterThan[int], 3)), []int{2}},
+		"AnyOf":  {AnyOf(Equals(-3), Equals(4)), []int{-3, 4}},
+		"NoneOf": {NoneOf(even, positive), []int{-3, -1}},
+		"In":     {In(0, 1, 5), []int{0, 1}},
+		"empty":  {AllOf[int](), data},
+	}
+	for name, test := range testcases {
+		if have := fsl.Filter(test.pred, data); !reflect.DeepEqual(have, test.expect) {
+			t.Errorf("%s: Filter(pred, %v) = %v, expected %v", name, data, have, test.expect)
+		}
+	}
+	if AnyOf[int]()(0) {
+		t.Errorf("AnyOf()(0) = true, expected false")
+	}
+}
+
+func TestShortCircuit(t *testing.T) {
+	calls := 0
+	never := func(int) bool { calls += 1; return true }
+	And(Equals(1), never)(0)
+	Or(Equals(1), never)(1)
+	AllOf(Equals(1), never)(0)
+	AnyOf(Equals(1), never)(1)
+	if calls != 0 {
+		t.Errorf("second predicate was called %d times, expected 0", calls)
+	}
+}
+
+func TestOn(t *testing.T) {
+	people := []person{{"Alice", 30}, {"Bob", 17}, {"Carol", 65}}
+	age := func(p person) int { return p.age }
+	name := func(p person) string { return p.name }
+	adult := On(age, fn.Partial1(fn.Flip(opr.GreaterThanOrEqual[int]), 18))
+	startsWithC := On(name, fn.Partial1(fn.Flip(strings.HasPrefix), "C"))
+	have := fsl.Filter(And(adult, Not(startsWithC)), people)
+	if expect := []person{{"Alice", 30}}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("Filter(adult and not C, %v) = %v, expected %v", people, have, expect)
+	}
+}
+
+func TestIn(t *testing.T) {
+	xs := []string{"a", "b"}
+	in := In(xs...)
+	xs[0] = "z"
+	if !in("a") || in("z") {
+		t.Errorf("In does not copy its arguments")
+	}
+}
@@ -0,0 +1,78 @@
+// Package reader defines computations that read from a shared environment,
+// such as configuration. The environment is passed implicitly through Map and
+// FlatMap, and explicitly only once, to Run.
+package reader
+
+// A Reader is a computation of a value of type A from an environment of
+// type R.
+type Reader[R, A any] func(R) A
+
+// Run a computation with an environment.
+func (r Reader[R, A]) Run(env R) A {
+	return r(env)
+}
+
+// Of creates a computation that ignores the environment and returns a.
+func Of[R, A any](a A) Reader[R, A] {
+	return func(R) A {
+		return a
+	}
+}
+
+// Ask returns the environment itself.
+func Ask[R any]() Reader[R, R] {
+	return func(env R) R {
+		return env
+	}
+}
+
+// Asks returns a value derived from the environment, e.g. a field of it.
+func Asks[R, A any](fn func(R) A) Reader[R, A] {
+	return fn
+}
+
+// Local runs a computation with a modified environment.
+func Local[R, A any](fn func(R) R, r Reader[R, A]) Reader[R, A] {
+	return func(env R) A {
+		return r(fn(env))
+	}
+}
+
+// Map applies a unary function to the result of a computation.
+func Map[R, A, B any](fn func(A) B, r Reader[R, A]) Reader[R, B] {
+	return func(env R) B {
+		return fn(r(env))
+	}
+}
+
+// FlatMap chains a computation to the result of another one. Both
+// computations read the same environment.
+func FlatMap[R, A, B any](fn func(A) Reader[R, B], r Reader[R, A]) Reader[R, B] {
+	return func(env R) B {
+		return fn(r(env))(env)
+	}
+}
+
+// Sequence runs a slice of computations with the same environment and
+// collects the results.
+func Sequence[R, A any](rs []Reader[R, A]) Reader[R, []A] {
+	return func(env R) []A {
+		var xs = make([]A, len(rs))
+		for i, r := range rs {
+			xs[i] = r(env)
+		}
+		return xs
+	}
+}
+
+// Traverse applies a function, which returns a computation, to each element
+// of a slice and collects the results.
+func Traverse[R, A, B any](fn func(A) Reader[R, B], xs []A) Reader[R, []B] {
+	return func(env R) []B {
+		var ys = make([]B, len(xs))
+		for i, x := range xs {
+			ys[i] = fn(x)(env)
+		}
+		return ys
+	}
+}
@@ -0,0 +1,49 @@
+package reader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type config struct {
+	greeting string
+	shout    bool
+}
+
+func greet(name string) Reader[config, string] {
+	return FlatMap(func(cfg config) Reader[config, string] {
+		msg := cfg.greeting + ", " + name
+		if cfg.shout {
+			msg = strings.ToUpper(msg)
+		}
+		return Of[config](msg)
+	}, Ask[config]())
+}
+
+func TestReader(t *testing.T) {
+	cfg := config{"Hello", false}
+	if have := greet("Alice").Run(cfg); have != "Hello, Alice" {
+		t.Errorf("greet(Alice) = %q, expected \"Hello, Alice\"", have)
+	}
+	loud := Local(func(c config) config { c.shout = true; return c }, greet("Bob"))
+	if have := loud.Run(cfg); have != "HELLO, BOB" {
+		t.Errorf("Local(shout, greet(Bob)) = %q, expected \"HELLO, BOB\"", have)
+	}
+	length := Map(func(s string) int { return len(s) }, Asks(func(c config) string { return c.greeting }))
+	if have := length.Run(cfg); have != 5 {
+		t.Errorf("len(greeting) = %d, expected 5", have)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	cfg := config{"Hi", false}
+	have := Traverse(greet, []string{"A", "B"}).Run(cfg)
+	if expect := []string{"Hi, A", "Hi, B"}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("Traverse(greet, [A B]) = %v, expected %v", have, expect)
+	}
+	have = Sequence([]Reader[config, string]{greet("C"), Of[config]("x")}).Run(cfg)
+	if expect := []string{"Hi, C", "x"}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("Sequence = %v, expected %v", have, expect)
+	}
+}
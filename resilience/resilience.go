@@ -0,0 +1,289 @@
+// Package resilience defines decorators for fallible functions of type
+// func(A) (B, error), such as calls to remote services. Each decorator returns
+// a function of the same type, so decorators can be stacked:
+//
+//	call := Fallback(CircuitBreaker(breaker, Retry(policy, fetch)), fromCache)
+//
+// All decorators that wait take a gofunctools.Clock, so they can be tested
+// without sleeping.
+package resilience
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	fts "github.com/basbiezemans/gofunctools"
+)
+
+// ErrTimeout is returned by a function decorated with Timeout if it does not
+// return in time.
+var ErrTimeout = errors.New("timeout")
+
+// ErrOpen is returned by a function decorated with CircuitBreaker while the
+// circuit is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// The failure that a Breaker records for a call that panics.
+var errPanic = errors.New("panic")
+
+func orSystem(clock fts.Clock) fts.Clock {
+	if clock == nil {
+		return fts.SystemClock
+	}
+	return clock
+}
+
+func sleep(clock fts.Clock, d time.Duration) {
+	if d > 0 {
+		<-clock.After(d)
+	}
+}
+
+// A Backoff returns the delay before retry n, where n starts at 1.
+type Backoff func(n int) time.Duration
+
+// Constant waits the same delay before every retry.
+func Constant(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// Exponential doubles the delay before every retry, starting at base, up to
+// a maximum.
+func Exponential(base, maximum time.Duration) Backoff {
+	return func(n int) time.Duration {
+		var d = base
+		for i := 1; i < n && d < maximum; i++ {
+			d *= 2
+		}
+		return min(d, maximum)
+	}
+}
+
+// Jitter randomizes the delays of a backoff. Each delay is drawn uniformly
+// from [0, d], which spreads out the retries of concurrent clients ("full
+// jitter"). If rng is nil, the global random number generator is used.
+func Jitter(b Backoff, rng *rand.Rand) Backoff {
+	var mu sync.Mutex
+	return func(n int) time.Duration {
+		var d = b(n)
+		if d <= 0 {
+			return 0
+		}
+		if rng == nil {
+			return rand.N(d + 1)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return time.Duration(rng.Int64N(int64(d) + 1))
+	}
+}
+
+// A RetryPolicy configures Retry.
+type RetryPolicy struct {
+	MaxAttempts int              // the number of calls, including the first; at least 1
+	Backoff     Backoff          // the delay before each retry; nil means no delay
+	Retryable   func(error) bool // which errors are retried; nil means all errors
+	Clock       fts.Clock        // nil means gofunctools.SystemClock
+}
+
+// Retry calls a function until it succeeds, it returns an error that is not
+// retryable, or the maximum number of attempts is reached. In the last two
+// cases, the last error is returned.
+func Retry[A, B any](policy RetryPolicy, fn func(A) (B, error)) func(A) (B, error) {
+	var clock = orSystem(policy.Clock)
+	return func(a A) (B, error) {
+		for n := 1; ; n++ {
+			b, err := fn(a)
+			if err == nil || n >= policy.MaxAttempts {
+				return b, err
+			}
+			if policy.Retryable != nil && !policy.Retryable(err) {
+				return b, err
+			}
+			if policy.Backoff != nil {
+				sleep(clock, policy.Backoff(n))
+			}
+		}
+	}
+}
+
+// Timeout returns ErrTimeout if a function does not return within d. The
+// function keeps running in its own goroutine after the timeout, and its
+// result is discarded; the function should limit its own running time if it
+// holds resources.
+func Timeout[A, B any](d time.Duration, clock fts.Clock, fn func(A) (B, error)) func(A) (B, error) {
+	clock = orSystem(clock)
+	type result struct {
+		b   B
+		err error
+	}
+	return func(a A) (B, error) {
+		var done = make(chan result, 1)
+		go func() {
+			b, err := fn(a)
+			done <- result{b, err}
+		}()
+		select {
+		case r := <-done:
+			return r.b, r.err
+		case <-clock.After(d):
+			var zero B
+			return zero, ErrTimeout
+		}
+	}
+}
+
+// Fallback calls an alternative function if a function fails.
+func Fallback[A, B any](fn func(A) (B, error), alt func(A) (B, error)) func(A) (B, error) {
+	return func(a A) (B, error) {
+		if b, err := fn(a); err == nil {
+			return b, nil
+		}
+		return alt(a)
+	}
+}
+
+// The State of a Breaker.
+type State string
+
+const (
+	Closed   State = "closed"
+	Open     State = "open"
+	HalfOpen State = "half-open"
+)
+
+// A Breaker is the state of a circuit breaker. It opens after a number of
+// consecutive failures and then rejects all calls with ErrOpen. After a
+// cooldown period it becomes half-open and lets a single trial call through:
+// if that call succeeds, the breaker closes, otherwise it opens again. A
+// Breaker can be shared by several decorated functions.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	clock     fts.Clock
+	state     State
+	failures  int
+	openedAt  time.Time
+}
+
+// Create a closed circuit breaker that opens after threshold consecutive
+// failures, for the duration of cooldown.
+func NewBreaker(threshold int, cooldown time.Duration, clock fts.Clock) *Breaker {
+	if threshold <= 0 {
+		panic("threshold must be positive")
+	}
+	return &Breaker{threshold: threshold, cooldown: cooldown, clock: orSystem(clock), state: Closed}
+}
+
+// State returns Closed, Open or HalfOpen. An open breaker whose cooldown has
+// passed is reported as HalfOpen.
+func (cb *Breaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == Open && cb.clock.Now().Sub(cb.openedAt) >= cb.cooldown {
+		return HalfOpen
+	}
+	return cb.state
+}
+
+// Determine whether a call may proceed.
+func (cb *Breaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case Closed:
+		return true
+	case Open:
+		if cb.clock.Now().Sub(cb.openedAt) >= cb.cooldown {
+			cb.state = HalfOpen
+			return true
+		}
+	}
+	// While half-open, only the trial call proceeds
+	return false
+}
+
+// Record the outcome of a call.
+func (cb *Breaker) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if err == nil {
+		cb.state, cb.failures = Closed, 0
+		return
+	}
+	cb.failures += 1
+	if cb.state == HalfOpen || cb.failures >= cb.threshold {
+		cb.state, cb.openedAt = Open, cb.clock.Now()
+	}
+}
+
+// CircuitBreaker protects a function with a circuit breaker. While the
+// breaker is open, the function is not called and ErrOpen is returned. If the
+// function panics, the breaker counts a failure and the panic goes on.
+func CircuitBreaker[A, B any](cb *Breaker, fn func(A) (B, error)) func(A) (B, error) {
+	return func(a A) (b B, err error) {
+		if !cb.allow() {
+			return b, ErrOpen
+		}
+		var panicked = true
+		defer func() {
+			if panicked {
+				err = errPanic
+			}
+			cb.record(err)
+		}()
+		b, err = fn(a)
+		panicked = false
+		return b, err
+	}
+}
+
+// A Limiter is a token bucket that allows n calls per interval on average,
+// with bursts of up to n calls. A Limiter can be shared by several decorated
+// functions.
+type Limiter struct {
+	mu     sync.Mutex
+	n      float64
+	per    time.Duration
+	clock  fts.Clock
+	tokens float64
+	last   time.Time
+}
+
+// Create a full token bucket that allows n calls per interval.
+func NewLimiter(n int, per time.Duration, clock fts.Clock) *Limiter {
+	if n <= 0 || per <= 0 {
+		panic("rate must be positive")
+	}
+	clock = orSystem(clock)
+	return &Limiter{n: float64(n), per: per, clock: clock, tokens: float64(n), last: clock.Now()}
+}
+
+// Reserve a token and return how long to wait before it is available.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var now = l.clock.Now()
+	var elapsed = now.Sub(l.last)
+	l.tokens = min(l.n, l.tokens+float64(elapsed)*l.n/float64(l.per))
+	l.last = now
+	l.tokens -= 1
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.per) / l.n)
+}
+
+// RateLimit delays calls to a function so they do not exceed the rate of a
+// limiter. Calls wait in the order in which they arrive.
+func RateLimit[A, B any](l *Limiter, fn func(A) (B, error)) func(A) (B, error) {
+	return func(a A) (B, error) {
+		sleep(l.clock, l.reserve())
+		return fn(a)
+	}
+}
@@ -0,0 +1,224 @@
+package resilience
+
+import (
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A fake clock that advances instantly when a timer is created, and records
+// the delays. If frozen, its timers never fire.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	delays []time.Duration
+	frozen bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ch = make(chan time.Time, 1)
+	if !c.frozen {
+		c.now = c.now.Add(d)
+		c.delays = append(c.delays, d)
+		ch <- c.now
+	}
+	return ch
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+var errTemporary = errors.New("temporary")
+var errPermanent = errors.New("permanent")
+
+// A function that fails n times with err before it succeeds.
+func flaky(n int, err error) (func(int) (string, error), *int) {
+	var calls = 0
+	return func(x int) (string, error) {
+		calls += 1
+		if calls <= n {
+			return "", err
+		}
+		return strconv.Itoa(x), nil
+	}, &calls
+}
+
+func TestBackoff(t *testing.T) {
+	exp := Exponential(100*time.Millisecond, time.Second)
+	var have []time.Duration
+	for n := 1; n <= 6; n++ {
+		have = append(have, exp(n))
+	}
+	expect := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i := range expect {
+		expect[i] *= time.Millisecond
+	}
+	if !reflect.DeepEqual(have, expect) {
+		t.Errorf("Exponential(100ms, 1s) = %v, expected %v", have, expect)
+	}
+	jittered := Jitter(exp, rand.New(rand.NewPCG(1, 2)))
+	for n := 1; n <= 100; n++ {
+		if d := jittered(n); d < 0 || d > exp(n) {
+			t.Errorf("Jitter(exp)(%d) = %v, expected within [0, %v]", n, d, exp(n))
+		}
+	}
+	if d := Jitter(Constant(0), nil)(1); d != 0 {
+		t.Errorf("Jitter(Constant(0))(1) = %v, expected 0", d)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	clock := &fakeClock{}
+	fn, calls := flaky(2, errTemporary)
+	policy := RetryPolicy{MaxAttempts: 5, Backoff: Exponential(time.Second, time.Minute), Clock: clock}
+	if have, err := Retry(policy, fn)(7); err != nil || have != "7" || *calls != 3 {
+		t.Errorf("Retry(flaky(2))(7) = %q, %v after %d calls, expected \"7\" after 3 calls", have, err, *calls)
+	}
+	if expect := []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(clock.delays, expect) {
+		t.Errorf("delays = %v, expected %v", clock.delays, expect)
+	}
+	fn, calls = flaky(10, errTemporary)
+	if _, err := Retry(policy, fn)(7); !errors.Is(err, errTemporary) || *calls != 5 {
+		t.Errorf("Retry(flaky(10)) = %v after %d calls, expected %v after 5 calls", err, *calls, errTemporary)
+	}
+	policy.Retryable = func(err error) bool { return !errors.Is(err, errPermanent) }
+	fn, calls = flaky(10, errPermanent)
+	if _, err := Retry(policy, fn)(7); !errors.Is(err, errPermanent) || *calls != 1 {
+		t.Errorf("Retry(permanent) = %v after %d calls, expected 1 call", err, *calls)
+	}
+	fn, calls = flaky(1, errTemporary)
+	if _, err := Retry(RetryPolicy{}, fn)(7); err == nil || *calls != 1 {
+		t.Errorf("Retry with zero policy made %d calls, expected 1", *calls)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	slow := func(x int) (int, error) {
+		<-release
+		return x, nil
+	}
+	if _, err := Timeout(time.Second, &fakeClock{}, slow)(1); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Timeout(slow)(1) = %v, expected %v", err, ErrTimeout)
+	}
+	fast := func(x int) (int, error) { return x, errTemporary }
+	if have, err := Timeout(time.Second, &fakeClock{frozen: true}, fast)(1); have != 1 || !errors.Is(err, errTemporary) {
+		t.Errorf("Timeout(fast)(1) = %v, %v, expected 1, %v", have, err, errTemporary)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	fn, _ := flaky(1, errTemporary)
+	cached := func(int) (string, error) { return "cached", nil }
+	call := Fallback(fn, cached)
+	if have, err := call(5); err != nil || have != "cached" {
+		t.Errorf("first call = %q, %v, expected \"cached\"", have, err)
+	}
+	if have, err := call(5); err != nil || have != "5" {
+		t.Errorf("second call = %q, %v, expected \"5\"", have, err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := &fakeClock{}
+	cb := NewBreaker(3, time.Minute, clock)
+	fn, calls := flaky(4, errTemporary)
+	call := CircuitBreaker(cb, fn)
+	for range 3 {
+		if _, err := call(1); !errors.Is(err, errTemporary) {
+			t.Fatalf("call = %v, expected %v", err, errTemporary)
+		}
+	}
+	if cb.State() != Open {
+		t.Fatalf("State() = %v after 3 failures, expected %v", cb.State(), Open)
+	}
+	if _, err := call(1); !errors.Is(err, ErrOpen) || *calls != 3 {
+		t.Errorf("call on open breaker = %v after %d calls, expected %v", err, *calls, ErrOpen)
+	}
+	clock.advance(time.Minute)
+	if cb.State() != HalfOpen {
+		t.Errorf("State() = %v after cooldown, expected %v", cb.State(), HalfOpen)
+	}
+	// The trial call fails, so the breaker opens again
+	if _, err := call(1); !errors.Is(err, errTemporary) || cb.State() != Open {
+		t.Errorf("trial call = %v, state %v, expected %v, %v", err, cb.State(), errTemporary, Open)
+	}
+	clock.advance(time.Minute)
+	if have, err := call(1); err != nil || have != "1" || cb.State() != Closed {
+		t.Errorf("second trial call = %q, %v, state %v, expected \"1\", %v", have, err, cb.State(), Closed)
+	}
+}
+
+func TestCircuitBreakerPanic(t *testing.T) {
+	clock := &fakeClock{}
+	cb := NewBreaker(1, time.Minute, clock)
+	var fail = true
+	call := CircuitBreaker(cb, func(x int) (int, error) {
+		if fail {
+			panic("boom")
+		}
+		return x, nil
+	})
+	mustPanic := func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("call did not pass on the panic")
+			}
+		}()
+		call(1)
+	}
+	// A panic counts as a failure, also for the trial call of a half-open
+	// breaker, which must not keep the breaker half-open
+	mustPanic()
+	if cb.State() != Open {
+		t.Fatalf("State() = %v after a panic, expected %v", cb.State(), Open)
+	}
+	clock.advance(time.Minute)
+	mustPanic()
+	if cb.State() != Open {
+		t.Fatalf("State() = %v after a panicking trial call, expected %v", cb.State(), Open)
+	}
+	clock.advance(time.Minute)
+	fail = false
+	if have, err := call(1); err != nil || have != 1 || cb.State() != Closed {
+		t.Errorf("trial call = %d, %v, state %v, expected 1, <nil>, %v", have, err, cb.State(), Closed)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	clock := &fakeClock{}
+	limiter := NewLimiter(2, time.Second, clock)
+	call := RateLimit(limiter, func(x int) (int, error) { return x, nil })
+	for i := range 5 {
+		call(i)
+	}
+	// A burst of 2 calls, then one call per half second
+	expect := []time.Duration{500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}
+	if !reflect.DeepEqual(clock.delays, expect) {
+		t.Errorf("delays = %v, expected %v", clock.delays, expect)
+	}
+	clock.advance(10 * time.Second)
+	clock.delays = nil
+	for i := range 2 {
+		call(i)
+	}
+	if len(clock.delays) != 0 {
+		t.Errorf("delays after refill = %v, expected none", clock.delays)
+	}
+}
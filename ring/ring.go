@@ -0,0 +1,117 @@
+// Package ring defines a fixed-size ring buffer that keeps the most recent
+// elements. When the buffer is full, adding an element evicts the oldest one.
+package ring
+
+import "iter"
+
+// A Buffer holds up to a fixed number of the most recently added elements.
+// The zero Buffer is not usable; create one with New or FromSeq.
+type Buffer[A any] struct {
+	buf   []A
+	start int // index of the oldest element in buf
+	n     int // number of elements
+}
+
+// Create an empty buffer with a capacity, which must be positive.
+func New[A any](capacity int) *Buffer[A] {
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+	return &Buffer[A]{buf: make([]A, capacity)}
+}
+
+// Create a buffer that holds the last elements of an iterator. The iterator
+// has to be finite.
+func FromSeq[A any](capacity int, seq iter.Seq[A]) *Buffer[A] {
+	var b = New[A](capacity)
+	for a := range seq {
+		b.Push(a)
+	}
+	return b
+}
+
+// Len returns the number of elements.
+func (b *Buffer[A]) Len() int {
+	return b.n
+}
+
+// Cap returns the capacity.
+func (b *Buffer[A]) Cap() int {
+	return len(b.buf)
+}
+
+// Full determines whether the buffer holds as many elements as its capacity.
+func (b *Buffer[A]) Full() bool {
+	return b.n == len(b.buf)
+}
+
+// Push adds an element. If the buffer is full, the oldest element is evicted
+// and returned with true.
+func (b *Buffer[A]) Push(a A) (A, bool) {
+	if b.n < len(b.buf) {
+		b.buf[(b.start+b.n)%len(b.buf)] = a
+		b.n += 1
+		var zero A
+		return zero, false
+	}
+	var evicted = b.buf[b.start]
+	b.buf[b.start] = a
+	b.start = (b.start + 1) % len(b.buf)
+	return evicted, true
+}
+
+// At returns the i-th oldest element, where 0 is the oldest one. It panics if
+// i is out of range.
+func (b *Buffer[A]) At(i int) A {
+	if i < 0 || i >= b.n {
+		panic("ring buffer index out of range")
+	}
+	return b.buf[(b.start+i)%len(b.buf)]
+}
+
+// Oldest returns the oldest element, or false if the buffer is empty.
+func (b *Buffer[A]) Oldest() (A, bool) {
+	if b.n == 0 {
+		var zero A
+		return zero, false
+	}
+	return b.At(0), true
+}
+
+// Newest returns the most recently added element, or false if the buffer is
+// empty.
+func (b *Buffer[A]) Newest() (A, bool) {
+	if b.n == 0 {
+		var zero A
+		return zero, false
+	}
+	return b.At(b.n - 1), true
+}
+
+// Clear removes all elements.
+func (b *Buffer[A]) Clear() {
+	clear(b.buf)
+	b.start, b.n = 0, 0
+}
+
+// All returns an iterator over the elements from oldest to newest.
+func (b *Buffer[A]) All() iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for i := range b.n {
+			if !yield(b.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements from newest to oldest.
+func (b *Buffer[A]) Backward() iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for i := b.n - 1; i >= 0; i-- {
+			if !yield(b.At(i)) {
+				return
+			}
+		}
+	}
+}
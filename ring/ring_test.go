@@ -0,0 +1,53 @@
+package ring
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/basbiezemans/gofunctools/iters"
+)
+
+func TestBuffer(t *testing.T) {
+	b := New[int](3)
+	if _, ok := b.Oldest(); ok {
+		t.Errorf("Oldest() on empty buffer = true, expected false")
+	}
+	var evicted []int
+	for i := range 5 {
+		if x, ok := b.Push(i); ok {
+			evicted = append(evicted, x)
+		}
+	}
+	if !reflect.DeepEqual(evicted, []int{0, 1}) {
+		t.Errorf("evicted %v, expected [0 1]", evicted)
+	}
+	if have := slices.Collect(b.All()); !reflect.DeepEqual(have, []int{2, 3, 4}) {
+		t.Errorf("All() = %v, expected [2 3 4]", have)
+	}
+	if have := slices.Collect(b.Backward()); !reflect.DeepEqual(have, []int{4, 3, 2}) {
+		t.Errorf("Backward() = %v, expected [4 3 2]", have)
+	}
+	oldest, _ := b.Oldest()
+	newest, _ := b.Newest()
+	if oldest != 2 || newest != 4 || !b.Full() || b.Len() != 3 || b.Cap() != 3 {
+		t.Errorf("Oldest, Newest = %d, %d; Len, Cap = %d, %d", oldest, newest, b.Len(), b.Cap())
+	}
+	b.Clear()
+	if b.Len() != 0 || len(slices.Collect(b.All())) != 0 {
+		t.Errorf("buffer is not empty after Clear()")
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	// Keep the last 4 of 100 values
+	naturals := iters.Unfold(func(n int) (int, int, bool) { return n, n + 1, n < 100 }, 0)
+	b := FromSeq(4, naturals)
+	if have := slices.Collect(b.All()); !reflect.DeepEqual(have, []int{96, 97, 98, 99}) {
+		t.Errorf("FromSeq(4, 0..99) = %v, expected [96 97 98 99]", have)
+	}
+	partial := FromSeq(5, slices.Values([]string{"a", "b"}))
+	if have := slices.Collect(partial.All()); !reflect.DeepEqual(have, []string{"a", "b"}) || partial.Full() {
+		t.Errorf("FromSeq(5, [a b]) = %v, full %v", have, partial.Full())
+	}
+}
@@ -2,6 +2,12 @@
 // slices of any type.
 package slices
 
+import (
+	"github.com/basbiezemans/gofunctools/option"
+	"github.com/basbiezemans/gofunctools/pair"
+	"github.com/basbiezemans/gofunctools/trampoline"
+)
+
 // Any, applied to a predicate and a slice, determines whether any element of
 // the slice satisfies the predicate.
 func Any[A any](fn func(A) bool, xs []A) bool {
@@ -51,6 +57,23 @@ func FoldRight[A, B any](fn func(A, B) B, initValue B, xs []A) B {
 	return acc
 }
 
+// FoldRightT is a lazy variant of FoldRight. The reducer function receives
+// the fold of the remaining elements as a suspended computation, which it can
+// extend with trampoline.FlatMap or discard to stop early. It runs in constant
+// stack space, regardless of the length of the slice.
+func FoldRightT[A, B any](fn func(A, trampoline.Trampoline[B]) trampoline.Trampoline[B], initValue B, xs []A) B {
+	var fold func(int) trampoline.Trampoline[B]
+	fold = func(i int) trampoline.Trampoline[B] {
+		if i == len(xs) {
+			return trampoline.Done(initValue)
+		}
+		return fn(xs[i], trampoline.More(func() trampoline.Trampoline[B] {
+			return fold(i + 1)
+		}))
+	}
+	return trampoline.Run(fold(0))
+}
+
 // ReduceLeft, applied to a reducer function and a non-empty slice, reduces the
 // slice to a single value. The accumulated value must be of the same type as
 // the slice elements. This function is non-total and will panic if the slice
@@ -74,6 +97,24 @@ func ReduceRight[A any](fn func(A, A) A, xs []A) A {
 	return FoldRight(fn, xs[n], xs[:n])
 }
 
+// ReduceLeftOpt is a total variant of ReduceLeft. It returns None, instead of
+// panicking, if the slice happens to be empty.
+func ReduceLeftOpt[A any](fn func(A, A) A, xs []A) option.Option[A] {
+	if len(xs) == 0 {
+		return option.None[A]()
+	}
+	return option.Some(ReduceLeft(fn, xs))
+}
+
+// ReduceRightOpt is a total variant of ReduceRight. It returns None, instead
+// of panicking, if the slice happens to be empty.
+func ReduceRightOpt[A any](fn func(A, A) A, xs []A) option.Option[A] {
+	if len(xs) == 0 {
+		return option.None[A]()
+	}
+	return option.Some(ReduceRight(fn, xs))
+}
+
 // Map applies a unary function to each element of a slice.
 func Map[A, B any](fn func(A) B, xs []A) []B {
 	var ys = make([]B, len(xs))
@@ -300,6 +341,170 @@ func ConcatMap[A, B any](fn func(A) []B, xs []A) []B {
 	return ys
 }
 
+// Concat concatenates a number of slices into a single slice.
+func Concat[A any](xss ...[]A) []A {
+	return Flatten(xss)
+}
+
+// Flatten concatenates a slice of slices into a single slice.
+func Flatten[A any](xss [][]A) []A {
+	var n = 0
+	for _, xs := range xss {
+		n += len(xs)
+	}
+	var ys = make([]A, 0, n)
+	for _, xs := range xss {
+		ys = append(ys, xs...)
+	}
+	return ys
+}
+
+// FlatMap is an alias of ConcatMap.
+func FlatMap[A, B any](fn func(A) []B, xs []A) []B {
+	return ConcatMap(fn, xs)
+}
+
+// Interleave, applied to two slices, alternates their elements, starting with
+// the first slice. It stops as soon as either slice is exhausted.
+func Interleave[A any](xs, ys []A) []A {
+	var n = min(len(xs), len(ys))
+	var zs = make([]A, 0, 2*n+1)
+	for i := range n {
+		zs = append(zs, xs[i], ys[i])
+	}
+	if len(xs) > n {
+		zs = append(zs, xs[n])
+	}
+	return zs
+}
+
+// Intersperse, applied to a separator and a slice, intersperses the separator
+// between the elements of the slice.
+func Intersperse[A any](sep A, xs []A) []A {
+	var ys = make([]A, 0, max(0, 2*len(xs)-1))
+	for i, x := range xs {
+		if i > 0 {
+			ys = append(ys, sep)
+		}
+		ys = append(ys, x)
+	}
+	return ys
+}
+
+// RoundRobin takes one element from each slice in turn, skipping slices that
+// are exhausted, until all slices are exhausted.
+func RoundRobin[A any](xss ...[]A) []A {
+	var n, m = 0, 0
+	for _, xs := range xss {
+		n += len(xs)
+		m = max(m, len(xs))
+	}
+	var ys = make([]A, 0, n)
+	for i := range m {
+		for _, xs := range xss {
+			if i < len(xs) {
+				ys = append(ys, xs[i])
+			}
+		}
+	}
+	return ys
+}
+
+// Product, applied to a combiner function and two slices, combines each
+// element of the first slice with each element of the second slice
+// (cartesian product).
+func Product[A, B, C any](fn func(A, B) C, xs []A, ys []B) []C {
+	var zs = make([]C, 0, len(xs)*len(ys))
+	for _, x := range xs {
+		for _, y := range ys {
+			zs = append(zs, fn(x, y))
+		}
+	}
+	return zs
+}
+
+// Take, applied to a number n and a slice, returns the first n elements of
+// the slice, or the slice itself if n exceeds its length.
+func Take[A any](n int, xs []A) []A {
+	return xs[:clamp(n, 0, len(xs))]
+}
+
+// Drop, applied to a number n and a slice, returns the slice after the first
+// n elements are dropped.
+func Drop[A any](n int, xs []A) []A {
+	return xs[clamp(n, 0, len(xs)):]
+}
+
+// SplitAt, applied to a number n and a slice, splits the slice into a prefix
+// of length n and the remainder of the slice. It is equivalent to
+// (Take(n, xs), Drop(n, xs)).
+func SplitAt[A any](n int, xs []A) ([]A, []A) {
+	return Take(n, xs), Drop(n, xs)
+}
+
+// Span, applied to a predicate and a slice, splits the slice into the longest
+// prefix of elements that satisfy the predicate and the remainder of the
+// slice. It is equivalent to (TakeWhile(fn, xs), DropWhile(fn, xs)).
+func Span[A any](fn func(A) bool, xs []A) ([]A, []A) {
+	for i, x := range xs {
+		if !fn(x) {
+			return xs[:i], xs[i:]
+		}
+	}
+	return xs, []A{}
+}
+
+// Break, applied to a predicate and a slice, splits the slice into the longest
+// prefix of elements that do not satisfy the predicate and the remainder of
+// the slice.
+func Break[A any](fn func(A) bool, xs []A) ([]A, []A) {
+	return Span(func(x A) bool { return !fn(x) }, xs)
+}
+
+// Nth, applied to an index and a slice, returns the element at the given
+// (zero-based) index (a,true), or (zero,false) if the index is out of range.
+func Nth[A any](n int, xs []A) (A, bool) {
+	var zero A
+	if n >= 0 && n < len(xs) {
+		return xs[n], true
+	}
+	return zero, false
+}
+
+// First returns the first element of a slice (a,true), or (zero,false) if the
+// slice is empty.
+func First[A any](xs []A) (A, bool) {
+	return Nth(0, xs)
+}
+
+// Last returns the last element of a slice (a,true), or (zero,false) if the
+// slice is empty.
+func Last[A any](xs []A) (A, bool) {
+	return Nth(len(xs)-1, xs)
+}
+
+// StepBy, applied to a step size and a slice, returns the first element and
+// every step-th element after it. The step size must be positive.
+func StepBy[A any](step int, xs []A) []A {
+	if step <= 0 {
+		panic("step must be positive")
+	}
+	var ys = make([]A, 0, (len(xs)+step-1)/step)
+	for i := 0; i < len(xs); i += step {
+		ys = append(ys, xs[i])
+	}
+	return ys
+}
+
+// Enumerate pairs each element of a slice with its (zero-based) index.
+func Enumerate[A any](xs []A) []pair.Pair[int, A] {
+	var ys = make([]pair.Pair[int, A], len(xs))
+	for i, x := range xs {
+		ys[i] = pair.New(i, x)
+	}
+	return ys
+}
+
 // Return an element of a slice or a default value if the index is out of range
 func getOrDefault[T any](i int, defValue T, xs []T) T {
 	if i >= 0 && i < len(xs) {
@@ -307,3 +512,8 @@ func getOrDefault[T any](i int, defValue T, xs []T) T {
 	}
 	return defValue
 }
+
+// Limit a value to the closed interval [lo, hi]
+func clamp(x, lo, hi int) int {
+	return max(lo, min(x, hi))
+}
@@ -9,6 +9,7 @@ import (
 	"unicode"
 
 	"github.com/basbiezemans/gofunctools/pair"
+	"github.com/basbiezemans/gofunctools/trampoline"
 )
 
 func TestAny(t *testing.T) {
@@ -75,6 +76,19 @@ func TestReduceRight(t *testing.T) {
 	}
 }
 
+func TestReduceOpt(t *testing.T) {
+	numbers := []int{1, 2, 3, 4}
+	if result, ok := ReduceLeftOpt(subtract, numbers).Get(); !ok || result != -8 {
+		t.Errorf("ReduceLeftOpt(subtract, %v) = (%d, %t), expected (-8, true)", numbers, result, ok)
+	}
+	if result, ok := ReduceRightOpt(subtract, numbers).Get(); !ok || result != -2 {
+		t.Errorf("ReduceRightOpt(subtract, %v) = (%d, %t), expected (-2, true)", numbers, result, ok)
+	}
+	if ReduceLeftOpt(subtract, []int{}).IsSome() || ReduceRightOpt(subtract, []int{}).IsSome() {
+		t.Errorf("ReduceLeftOpt/ReduceRightOpt(subtract, []) = Some, expected None")
+	}
+}
+
 func TestFoldLeft(t *testing.T) {
 	type TestCase struct {
 		callb  func(int, int) int
@@ -148,6 +162,34 @@ func TestFoldRight(t *testing.T) {
 	}
 }
 
+func TestFoldRightT(t *testing.T) {
+	n := 1_000_000
+	numbers := make([]int, n)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	sum := func(x int, rest trampoline.Trampoline[int]) trampoline.Trampoline[int] {
+		return trampoline.Map(func(acc int) int { return x + acc }, rest)
+	}
+	expect := n * (n + 1) / 2
+	result := FoldRightT(sum, 0, numbers)
+	if result != expect {
+		t.Errorf("FoldRightT(sum, 0, [1..%d]) = %d, expected %d", n, result, expect)
+	}
+	visited := 0
+	firstEven := func(x int, rest trampoline.Trampoline[int]) trampoline.Trampoline[int] {
+		visited += 1
+		if even(x) {
+			return trampoline.Done(x)
+		}
+		return rest
+	}
+	result = FoldRightT(firstEven, -1, numbers)
+	if result != 2 || visited != 2 {
+		t.Errorf("FoldRightT(firstEven, -1, [1..%d]) = %d, visited %d, expected 2, visited 2", n, result, visited)
+	}
+}
+
 func TestMap(t *testing.T) {
 	expect := []int{2, 4, 6, 8}
 	result := Map(double, []int{1, 2, 3, 4})
@@ -441,6 +483,149 @@ func BenchmarkConcatMap(b *testing.B) {
 	}
 }
 
+func TestTakeDrop(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	type TestCase struct {
+		n    int
+		take []int
+		drop []int
+	}
+	testcases := []TestCase{
+		{2, []int{1, 2}, []int{3, 4}},
+		{0, []int{}, []int{1, 2, 3, 4}},
+		{-1, []int{}, []int{1, 2, 3, 4}},
+		{6, []int{1, 2, 3, 4}, []int{}},
+	}
+	for _, test := range testcases {
+		result := Take(test.n, data)
+		if !reflect.DeepEqual(result, test.take) {
+			t.Errorf("Take(%d, %v) = %v, expected %v", test.n, data, result, test.take)
+		}
+		result = Drop(test.n, data)
+		if !reflect.DeepEqual(result, test.drop) {
+			t.Errorf("Drop(%d, %v) = %v, expected %v", test.n, data, result, test.drop)
+		}
+		result1, result2 := SplitAt(test.n, data)
+		if !reflect.DeepEqual(result1, test.take) || !reflect.DeepEqual(result2, test.drop) {
+			t.Errorf("SplitAt(%d, %v) = %v, %v, expected %v, %v", test.n, data, result1, result2, test.take, test.drop)
+		}
+	}
+}
+
+func TestSpanBreak(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	expect1, expect2 := []int{1, 2}, []int{3, 4}
+	result1, result2 := Span(lessThan(3), data)
+	if !reflect.DeepEqual(result1, expect1) || !reflect.DeepEqual(result2, expect2) {
+		t.Errorf("Span(lessThan(3), %v) = %v, %v, expected %v, %v", data, result1, result2, expect1, expect2)
+	}
+	result1, result2 = Break(greaterThan(2), data)
+	if !reflect.DeepEqual(result1, expect1) || !reflect.DeepEqual(result2, expect2) {
+		t.Errorf("Break(greaterThan(2), %v) = %v, %v, expected %v, %v", data, result1, result2, expect1, expect2)
+	}
+	expect1, expect2 = []int{1, 2, 3, 4}, []int{}
+	result1, result2 = Span(lessThan(5), data)
+	if !reflect.DeepEqual(result1, expect1) || !reflect.DeepEqual(result2, expect2) {
+		t.Errorf("Span(lessThan(5), %v) = %v, %v, expected %v, %v", data, result1, result2, expect1, expect2)
+	}
+}
+
+func TestNthFirstLast(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	if x, ok := Nth(2, data); !ok || x != 3 {
+		t.Errorf("Nth(2, %v) = (%d, %t), expected (3, true)", data, x, ok)
+	}
+	if x, ok := Nth(4, data); ok || x != 0 {
+		t.Errorf("Nth(4, %v) = (%d, %t), expected (0, false)", data, x, ok)
+	}
+	if x, ok := First(data); !ok || x != 1 {
+		t.Errorf("First(%v) = (%d, %t), expected (1, true)", data, x, ok)
+	}
+	if x, ok := Last(data); !ok || x != 4 {
+		t.Errorf("Last(%v) = (%d, %t), expected (4, true)", data, x, ok)
+	}
+	if _, ok := Last([]int{}); ok {
+		t.Errorf("Last([]) = (_, true), expected (_, false)")
+	}
+}
+
+func TestStepBy(t *testing.T) {
+	data := []int{0, 1, 2, 3, 4, 5, 6}
+	expect := []int{0, 3, 6}
+	result := StepBy(3, data)
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("StepBy(3, %v) = %v, expected %v", data, result, expect)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	data := []string{"a", "b"}
+	expect := []pair.Pair[int, string]{
+		pair.New(0, "a"), pair.New(1, "b"),
+	}
+	result := Enumerate(data)
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Enumerate(%v) = %v, expected %v", data, result, expect)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	expect := []int{1, 2, 3, 4, 5}
+	result := Concat([]int{1, 2}, []int{}, []int{3, 4, 5})
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Concat([1 2], [], [3 4 5]) = %v, expected %v", result, expect)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	input := [][]int{{1}, {}, {2, 3}}
+	expect := []int{1, 2, 3}
+	result := Flatten(input)
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Flatten(%v) = %v, expected %v", input, result, expect)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	testcases := []map[string][]int{
+		{"nums1": {1, 3, 5}, "nums2": {2, 4, 6}, "expect": {1, 2, 3, 4, 5, 6}},
+		{"nums1": {1, 3, 5}, "nums2": {2}, "expect": {1, 2, 3}},
+		{"nums1": {1}, "nums2": {2, 4, 6}, "expect": {1, 2}},
+		{"nums1": {}, "nums2": {2, 4}, "expect": {}},
+	}
+	for _, tc := range testcases {
+		result := Interleave(tc["nums1"], tc["nums2"])
+		if !reflect.DeepEqual(result, tc["expect"]) {
+			t.Errorf("Interleave(%v, %v) = %v, expected %v", tc["nums1"], tc["nums2"], result, tc["expect"])
+		}
+	}
+}
+
+func TestIntersperse(t *testing.T) {
+	input := []string{"a", "b", "c"}
+	expect := []string{"a", ",", "b", ",", "c"}
+	result := Intersperse(",", input)
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Intersperse(\",\", %v) = %v, expected %v", input, result, expect)
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	expect := []int{1, 2, 3, 4, 5, 6}
+	result := RoundRobin([]int{1, 4, 6}, []int{2}, []int{3, 5})
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("RoundRobin([1 4 6], [2], [3 5]) = %v, expected %v", result, expect)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	expect := []int{10, 20, 30, 20, 40, 60}
+	result := Product(multiply, []int{1, 2}, []int{10, 20, 30})
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Product(multiply, [1 2], [10 20 30]) = %v, expected %v", result, expect)
+	}
+}
+
 // Helper functions
 
 func even(x int) bool {
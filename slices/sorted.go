@@ -0,0 +1,144 @@
+package slices
+
+import (
+	"container/heap"
+
+	"github.com/basbiezemans/gofunctools/internal/merge"
+)
+
+// The functions in this file require slices that are sorted in ascending
+// order by the given comparison function, which returns a negative number, zero
+// or a positive number like cmp.Compare. The functions that return a slice
+// return a new slice, which is empty but not nil if there are no elements.
+
+// BinarySearchBy searches for a target in a sorted slice and returns the
+// index of the first element that is not less than the target, and whether
+// that element equals the target. The comparison function compares an element
+// with the target, so the target can be a key, like an ID of a record.
+func BinarySearchBy[A, T any](cmp func(A, T) int, target T, xs []A) (int, bool) {
+	var i = LowerBound(cmp, target, xs)
+	return i, i < len(xs) && cmp(xs[i], target) == 0
+}
+
+// LowerBound returns the index of the first element of a sorted slice that is
+// not less than the target, or len(xs) if there is none.
+func LowerBound[A, T any](cmp func(A, T) int, target T, xs []A) int {
+	var lo, hi = 0, len(xs)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp(xs[mid], target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound returns the index of the first element of a sorted slice that is
+// greater than the target, or len(xs) if there is none. The elements equal to
+// the target are xs[LowerBound(...):UpperBound(...)].
+func UpperBound[A, T any](cmp func(A, T) int, target T, xs []A) int {
+	var lo, hi = 0, len(xs)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp(xs[mid], target) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// MergeSorted merges sorted slices into a new sorted slice. It runs in
+// O(n log k) time for n elements in k slices. Equal elements keep the order of
+// the slices they come from.
+func MergeSorted[A any](cmp func(A, A) int, xss ...[]A) []A {
+	var n = 0
+	var h = &merge.Heap[A]{Cmp: cmp}
+	var next = make([]int, len(xss)) // the index of the next value of each slice
+	for i, xs := range xss {
+		n += len(xs)
+		if len(xs) > 0 {
+			h.Items = append(h.Items, merge.Item[A]{Value: xs[0], Source: i})
+			next[i] = 1
+		}
+	}
+	heap.Init(h)
+	var result = make([]A, 0, n)
+	for h.Len() > 0 {
+		top := &h.Items[0]
+		result = append(result, top.Value)
+		if xs, j := xss[top.Source], next[top.Source]; j < len(xs) {
+			top.Value, next[top.Source] = xs[j], j+1
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return result
+}
+
+// Union returns the sorted union of two sorted slices in linear time. An
+// element that occurs m times in xs and n times in ys occurs max(m, n) times
+// in the result.
+func Union[A any](cmp func(A, A) int, xs, ys []A) []A {
+	var result = make([]A, 0, max(len(xs), len(ys)))
+	var i, j = 0, 0
+	for i < len(xs) && j < len(ys) {
+		switch c := cmp(xs[i], ys[j]); {
+		case c < 0:
+			result = append(result, xs[i])
+			i += 1
+		case c > 0:
+			result = append(result, ys[j])
+			j += 1
+		default:
+			result = append(result, xs[i])
+			i, j = i+1, j+1
+		}
+	}
+	result = append(result, xs[i:]...)
+	return append(result, ys[j:]...)
+}
+
+// Intersect returns the sorted intersection of two sorted slices in linear
+// time. An element that occurs m times in xs and n times in ys occurs
+// min(m, n) times in the result.
+func Intersect[A any](cmp func(A, A) int, xs, ys []A) []A {
+	var result = make([]A, 0, min(len(xs), len(ys)))
+	var i, j = 0, 0
+	for i < len(xs) && j < len(ys) {
+		switch c := cmp(xs[i], ys[j]); {
+		case c < 0:
+			i += 1
+		case c > 0:
+			j += 1
+		default:
+			result = append(result, xs[i])
+			i, j = i+1, j+1
+		}
+	}
+	return result
+}
+
+// Difference returns the elements of a sorted slice xs that are not in a
+// sorted slice ys, in linear time. An element that occurs m times in xs and n
+// times in ys occurs max(m-n, 0) times in the result.
+func Difference[A any](cmp func(A, A) int, xs, ys []A) []A {
+	var result = make([]A, 0, len(xs))
+	var i, j = 0, 0
+	for i < len(xs) && j < len(ys) {
+		switch c := cmp(xs[i], ys[j]); {
+		case c < 0:
+			result = append(result, xs[i])
+			i += 1
+		case c > 0:
+			j += 1
+		default:
+			i, j = i+1, j+1
+		}
+	}
+	return append(result, xs[i:]...)
+}
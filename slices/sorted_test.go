@@ -0,0 +1,128 @@
+package slices
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type record struct {
+	id   int
+	name string
+}
+
+func byID(r record, id int) int {
+	return cmp.Compare(r.id, id)
+}
+
+func TestBinarySearchBy(t *testing.T) {
+	records := []record{{2, "b"}, {3, "c"}, {5, "e"}, {8, "h"}}
+	testcases := map[int]struct {
+		index int
+		found bool
+	}{1: {0, false}, 2: {0, true}, 5: {2, true}, 6: {3, false}, 9: {4, false}}
+	for id, expect := range testcases {
+		i, found := BinarySearchBy(byID, id, records)
+		if i != expect.index || found != expect.found {
+			t.Errorf("BinarySearchBy(byID, %d) = %d, %v, expected %d, %v", id, i, found, expect.index, expect.found)
+		}
+	}
+	if i, found := BinarySearchBy(byID, 1, []record{}); i != 0 || found {
+		t.Errorf("BinarySearchBy on empty slice = %d, %v, expected 0, false", i, found)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	xs := []int{1, 2, 2, 2, 4, 4, 7}
+	testcases := map[int][2]int{0: {0, 0}, 1: {0, 1}, 2: {1, 4}, 3: {4, 4}, 4: {4, 6}, 7: {6, 7}, 8: {7, 7}}
+	for x, expect := range testcases {
+		lo, hi := LowerBound(cmp.Compare[int], x, xs), UpperBound(cmp.Compare[int], x, xs)
+		if lo != expect[0] || hi != expect[1] {
+			t.Errorf("LowerBound, UpperBound(%d, %v) = %d, %d, expected %v", x, xs, lo, hi, expect)
+		}
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	have := MergeSorted(cmp.Compare[int], []int{1, 4, 9}, nil, []int{2, 3, 10, 11}, []int{0, 4})
+	if expect := []int{0, 1, 2, 3, 4, 4, 9, 10, 11}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("MergeSorted = %v, expected %v", have, expect)
+	}
+	if have := MergeSorted(cmp.Compare[int]); len(have) != 0 {
+		t.Errorf("MergeSorted() = %v, expected []", have)
+	}
+	// Equal elements keep the order of their source slices
+	byLength := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	words := MergeSorted(byLength, []string{"b", "dd"}, []string{"a", "cc", "eee"}, []string{"f"})
+	if expect := []string{"b", "a", "f", "dd", "cc", "eee"}; !reflect.DeepEqual(words, expect) {
+		t.Errorf("MergeSorted(byLength) = %v, expected %v", words, expect)
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	var xss [][]int
+	var all []int
+	for range 20 {
+		xs := make([]int, rng.IntN(50))
+		for i := range xs {
+			xs[i] = rng.IntN(100)
+		}
+		slices.Sort(xs)
+		xss = append(xss, xs)
+		all = append(all, xs...)
+	}
+	slices.Sort(all)
+	if have := MergeSorted(cmp.Compare[int], xss...); !slices.Equal(have, all) {
+		t.Errorf("MergeSorted of 20 random slices is not sorted: %v", have)
+	}
+}
+
+func BenchmarkMergeSorted(b *testing.B) {
+	xss := make([][]int, 8)
+	for i := range xss {
+		xss[i] = make([]int, 100_000)
+		for j := range xss[i] {
+			xss[i][j] = j*len(xss) + i
+		}
+	}
+	for i := 0; i < b.N; i++ {
+		MergeSorted(cmp.Compare[int], xss...)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	xs := []int{1, 2, 2, 3, 5, 8}
+	ys := []int{2, 3, 3, 4, 8, 9}
+	testcases := map[string]struct {
+		fn     func(func(int, int) int, []int, []int) []int
+		expect []int
+	}{
+		"Union":      {Union[int], []int{1, 2, 2, 3, 3, 4, 5, 8, 9}},
+		"Intersect":  {Intersect[int], []int{2, 3, 8}},
+		"Difference": {Difference[int], []int{1, 2, 5}},
+	}
+	for name, test := range testcases {
+		if have := test.fn(cmp.Compare[int], xs, ys); !reflect.DeepEqual(have, test.expect) {
+			t.Errorf("%s(%v, %v) = %v, expected %v", name, xs, ys, have, test.expect)
+		}
+	}
+	if have := Union(strings.Compare, nil, []string{"a"}); !reflect.DeepEqual(have, []string{"a"}) {
+		t.Errorf("Union([], [a]) = %v, expected [a]", have)
+	}
+	if have := Difference(strings.Compare, []string{"a"}, nil); !reflect.DeepEqual(have, []string{"a"}) {
+		t.Errorf("Difference([a], []) = %v, expected [a]", have)
+	}
+	// An empty result is an empty slice, not nil
+	for name, test := range testcases {
+		if have := test.fn(cmp.Compare[int], nil, nil); have == nil || len(have) != 0 {
+			t.Errorf("%s([], []) = %#v, expected []int{}", name, have)
+		}
+	}
+	if have := Intersect(cmp.Compare[int], []int{1}, []int{2}); have == nil {
+		t.Errorf("Intersect([1], [2]) = nil, expected []int{}")
+	}
+	if have := Difference(cmp.Compare[int], []int{1}, []int{1}); have == nil {
+		t.Errorf("Difference([1], [1]) = nil, expected []int{}")
+	}
+}
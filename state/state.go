@@ -0,0 +1,103 @@
+// Package state defines computations that thread a state through a sequence
+// of steps, such as a counter or a random number generator. Each step
+// receives the state of the previous step and returns a new one.
+package state
+
+// A State is a computation that produces a value of type A and transforms a
+// state of type S.
+type State[S, A any] func(S) (A, S)
+
+// Run a computation with an initial state and return the value and the final
+// state.
+func (st State[S, A]) Run(s S) (A, S) {
+	return st(s)
+}
+
+// Eval runs a computation and returns the value.
+func (st State[S, A]) Eval(s S) A {
+	a, _ := st(s)
+	return a
+}
+
+// Exec runs a computation and returns the final state.
+func (st State[S, A]) Exec(s S) S {
+	_, s = st(s)
+	return s
+}
+
+// Of creates a computation that returns a and leaves the state unchanged.
+func Of[S, A any](a A) State[S, A] {
+	return func(s S) (A, S) {
+		return a, s
+	}
+}
+
+// Get returns the current state.
+func Get[S any]() State[S, S] {
+	return func(s S) (S, S) {
+		return s, s
+	}
+}
+
+// Gets returns a value derived from the current state.
+func Gets[S, A any](fn func(S) A) State[S, A] {
+	return func(s S) (A, S) {
+		return fn(s), s
+	}
+}
+
+// Put replaces the state.
+func Put[S any](s S) State[S, struct{}] {
+	return func(S) (struct{}, S) {
+		return struct{}{}, s
+	}
+}
+
+// Modify applies a function to the state.
+func Modify[S any](fn func(S) S) State[S, struct{}] {
+	return func(s S) (struct{}, S) {
+		return struct{}{}, fn(s)
+	}
+}
+
+// Map applies a unary function to the value of a computation.
+func Map[S, A, B any](fn func(A) B, st State[S, A]) State[S, B] {
+	return func(s S) (B, S) {
+		a, s := st(s)
+		return fn(a), s
+	}
+}
+
+// FlatMap chains a computation to the value of another one. The second
+// computation starts with the state that the first one left.
+func FlatMap[S, A, B any](fn func(A) State[S, B], st State[S, A]) State[S, B] {
+	return func(s S) (B, S) {
+		a, s := st(s)
+		return fn(a)(s)
+	}
+}
+
+// Sequence runs a slice of computations from left to right, threading the
+// state, and collects the values.
+func Sequence[S, A any](sts []State[S, A]) State[S, []A] {
+	return func(s S) ([]A, S) {
+		var xs = make([]A, len(sts))
+		for i, st := range sts {
+			xs[i], s = st(s)
+		}
+		return xs, s
+	}
+}
+
+// Traverse applies a function, which returns a computation, to each element
+// of a slice, runs the computations from left to right and collects the
+// values.
+func Traverse[S, A, B any](fn func(A) State[S, B], xs []A) State[S, []B] {
+	return func(s S) ([]B, S) {
+		var ys = make([]B, len(xs))
+		for i, x := range xs {
+			ys[i], s = fn(x)(s)
+		}
+		return ys, s
+	}
+}
@@ -0,0 +1,53 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+)
+
+// A label generator that threads a counter.
+func fresh(prefix string) State[int, string] {
+	return FlatMap(func(n int) State[int, string] {
+		return Map(func(struct{}) string {
+			return prefix + string(rune('a'+n))
+		}, Put(n+1))
+	}, Get[int]())
+}
+
+func TestState(t *testing.T) {
+	label, next := FlatMap(func(a string) State[int, string] {
+		return Map(func(b string) string { return a + b }, fresh("y"))
+	}, fresh("x")).Run(0)
+	if label != "xayb" || next != 2 {
+		t.Errorf("Run(0) = %q, %d, expected \"xayb\", 2", label, next)
+	}
+	if have := Modify(func(n int) int { return n * 10 }).Exec(4); have != 40 {
+		t.Errorf("Modify(*10).Exec(4) = %d, expected 40", have)
+	}
+	if have := Gets(func(n int) bool { return n > 3 }).Eval(4); !have {
+		t.Errorf("Gets(> 3).Eval(4) = false, expected true")
+	}
+	if have, s := Of[int]("x").Run(7); have != "x" || s != 7 {
+		t.Errorf("Of(x).Run(7) = %q, %d, expected \"x\", 7", have, s)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	labels, next := Traverse(fresh, []string{"p", "q", "r"}).Run(0)
+	if expect := []string{"pa", "qb", "rc"}; !reflect.DeepEqual(labels, expect) || next != 3 {
+		t.Errorf("Traverse(fresh, [p q r]) = %v, %d, expected %v, 3", labels, next, expect)
+	}
+	labels = Sequence([]State[int, string]{fresh("s"), fresh("t")}).Eval(5)
+	if expect := []string{"sf", "tg"}; !reflect.DeepEqual(labels, expect) {
+		t.Errorf("Sequence = %v, expected %v", labels, expect)
+	}
+	// Traverse runs in constant stack space
+	counts := Traverse(func(int) State[int, int] { return tick }, make([]int, 1_000_000)).Exec(0)
+	if counts != 1_000_000 {
+		t.Errorf("Traverse over 1000000 elements left state %d", counts)
+	}
+}
+
+func tick(n int) (int, int) {
+	return n, n + 1
+}
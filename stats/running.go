@@ -0,0 +1,450 @@
+package stats
+
+import (
+	"cmp"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+
+	opr "github.com/basbiezemans/gofunctools/operators"
+)
+
+// An Accumulator is a running aggregate over a stream of values of type A.
+// Add incorporates a value and Merge combines the aggregates of two
+// partitions of a stream. Both return the resulting aggregate of type S, so
+// that method expressions like RunningMean[float64].Add can be used as
+// reducer functions with slices.FoldLeft.
+//
+// The value types RunningMean, RunningVariance and EWMA are immutable: Add
+// returns a new aggregate, so iters.Scan yields an independent snapshot at
+// every step. The pointer types Reservoir, HyperLogLog and TopK are too large
+// to copy on every value, so their Add updates the receiver in place and
+// returns it. Every result of Add therefore aliases the same aggregate, and
+// iters.Scan over them yields the same pointer at every step. Their Merge
+// does return a new aggregate and leaves both operands unchanged.
+type Accumulator[A, S any] interface {
+	Add(A) S
+	Merge(S) S
+}
+
+// A Mergeable is an aggregate that can be combined with the aggregate of
+// another partition.
+type Mergeable[S any] interface {
+	Merge(S) S
+}
+
+// MergeAll merges the aggregates of a number of partitions.
+func MergeAll[S Mergeable[S]](s S, others ...S) S {
+	for _, o := range others {
+		s = s.Merge(o)
+	}
+	return s
+}
+
+// RunningMean is the running arithmetic mean of a stream of numbers. The
+// zero value is an empty aggregate.
+type RunningMean[T opr.Number] struct {
+	n    int
+	mean float64
+}
+
+// Add a value to the running mean.
+func (r RunningMean[T]) Add(x T) RunningMean[T] {
+	r.n += 1
+	r.mean += (float64(x) - r.mean) / float64(r.n)
+	return r
+}
+
+// Merge the running mean of another partition.
+func (r RunningMean[T]) Merge(o RunningMean[T]) RunningMean[T] {
+	if o.n == 0 {
+		return r
+	}
+	n := r.n + o.n
+	r.mean += (o.mean - r.mean) * float64(o.n) / float64(n)
+	r.n = n
+	return r
+}
+
+// Count returns the number of values.
+func (r RunningMean[T]) Count() int {
+	return r.n
+}
+
+// Mean returns the mean of the values, or an error if there are none.
+func (r RunningMean[T]) Mean() (float64, error) {
+	if r.n == 0 {
+		return 0, ErrEmpty
+	}
+	return r.mean, nil
+}
+
+// RunningVariance is the running mean and variance of a stream of numbers,
+// computed with Welford's algorithm. The zero value is an empty aggregate.
+type RunningVariance[T opr.Number] struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+// Add a value to the running variance.
+func (r RunningVariance[T]) Add(x T) RunningVariance[T] {
+	v := float64(x)
+	r.n += 1
+	delta := v - r.mean
+	r.mean += delta / float64(r.n)
+	r.m2 += delta * (v - r.mean)
+	return r
+}
+
+// Merge the running variance of another partition, using the parallel
+// algorithm of Chan et al.
+func (r RunningVariance[T]) Merge(o RunningVariance[T]) RunningVariance[T] {
+	if o.n == 0 {
+		return r
+	}
+	if r.n == 0 {
+		return o
+	}
+	n := float64(r.n + o.n)
+	delta := o.mean - r.mean
+	r.m2 += o.m2 + delta*delta*float64(r.n)*float64(o.n)/n
+	r.mean += delta * float64(o.n) / n
+	r.n += o.n
+	return r
+}
+
+// Count returns the number of values.
+func (r RunningVariance[T]) Count() int {
+	return r.n
+}
+
+// Mean returns the mean of the values, or an error if there are none.
+func (r RunningVariance[T]) Mean() (float64, error) {
+	if r.n == 0 {
+		return 0, ErrEmpty
+	}
+	return r.mean, nil
+}
+
+// Variance returns the population variance of the values.
+func (r RunningVariance[T]) Variance() (float64, error) {
+	if r.n == 0 {
+		return 0, ErrEmpty
+	}
+	return r.m2 / float64(r.n), nil
+}
+
+// SampleVariance returns the sample variance of the values, or ErrTooFew if
+// there are fewer than two.
+func (r RunningVariance[T]) SampleVariance() (float64, error) {
+	if r.n < 2 {
+		return 0, ErrTooFew
+	}
+	return r.m2 / float64(r.n-1), nil
+}
+
+// StdDev returns the population standard deviation of the values.
+func (r RunningVariance[T]) StdDev() (float64, error) {
+	v, err := r.Variance()
+	return math.Sqrt(v), err
+}
+
+// EWMA is the exponentially weighted moving average of a stream of numbers.
+// Each new value x updates the average to alpha*x + (1-alpha)*average. The
+// average is bias-corrected, so that it is not pulled towards zero while
+// there are only a few values.
+type EWMA[T opr.Number] struct {
+	alpha float64
+	sum   float64 // weighted sum, starting from zero
+	decay float64 // (1-alpha)^n
+}
+
+// NewEWMA creates an empty moving average with a smoothing factor alpha,
+// where 0 < alpha <= 1.
+func NewEWMA[T opr.Number](alpha float64) EWMA[T] {
+	if alpha <= 0 || alpha > 1 {
+		panic("alpha must be within (0, 1]")
+	}
+	return EWMA[T]{alpha, 0, 1}
+}
+
+// Add a value to the moving average.
+func (e EWMA[T]) Add(x T) EWMA[T] {
+	e.sum = e.alpha*float64(x) + (1-e.alpha)*e.sum
+	e.decay *= 1 - e.alpha
+	return e
+}
+
+// Merge the moving average of a partition that follows this one in time.
+// Unlike the other accumulators, merging is not commutative.
+func (e EWMA[T]) Merge(o EWMA[T]) EWMA[T] {
+	e.sum = o.decay*e.sum + o.sum
+	e.decay *= o.decay
+	return e
+}
+
+// Value returns the moving average, or an error if there are no values.
+func (e EWMA[T]) Value() (float64, error) {
+	if e.decay == 1 {
+		return 0, ErrEmpty
+	}
+	return e.sum / (1 - e.decay), nil
+}
+
+// Reservoir keeps a uniform random sample of fixed size of a stream of
+// numbers, which is used to estimate quantiles (Vitter's algorithm R). Add
+// updates the reservoir in place and returns the same pointer.
+type Reservoir[T opr.Number] struct {
+	size   int
+	n      int
+	sample []T
+	rng    *rand.Rand
+}
+
+// NewReservoir creates an empty reservoir that keeps a sample of the given
+// size. The random number generator determines which values are sampled.
+func NewReservoir[T opr.Number](size int, rng *rand.Rand) *Reservoir[T] {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+	return &Reservoir[T]{size, 0, make([]T, 0, size), rng}
+}
+
+// Add a value to the reservoir.
+func (r *Reservoir[T]) Add(x T) *Reservoir[T] {
+	r.n += 1
+	if len(r.sample) < r.size {
+		r.sample = append(r.sample, x)
+	} else if j := r.rng.IntN(r.n); j < r.size {
+		r.sample[j] = x
+	}
+	return r
+}
+
+// Merge the reservoir of another partition into a new reservoir. Values are
+// drawn from both samples in proportion to the sizes of their partitions.
+func (r *Reservoir[T]) Merge(o *Reservoir[T]) *Reservoir[T] {
+	m := NewReservoir[T](r.size, r.rng)
+	m.n = r.n + o.n
+	xs, ys := slices.Clone(r.sample), slices.Clone(o.sample)
+	for len(m.sample) < m.size && (len(xs) > 0 || len(ys) > 0) {
+		var src = &ys
+		if len(ys) == 0 || (len(xs) > 0 && m.rng.IntN(m.n) < r.n) {
+			src = &xs
+		}
+		i := m.rng.IntN(len(*src))
+		m.sample = append(m.sample, (*src)[i])
+		(*src)[i] = (*src)[len(*src)-1]
+		*src = (*src)[:len(*src)-1]
+	}
+	return m
+}
+
+// Count returns the number of values that were added.
+func (r *Reservoir[T]) Count() int {
+	return r.n
+}
+
+// Sample returns a copy of the sampled values.
+func (r *Reservoir[T]) Sample() []T {
+	return slices.Clone(r.sample)
+}
+
+// Quantile estimates the q-th quantile of the stream, where q lies within
+// [0, 1].
+func (r *Reservoir[T]) Quantile(q float64) (float64, error) {
+	return Percentile(100*q, r.sample)
+}
+
+// HyperLogLog estimates the number of distinct values in a stream using a
+// fixed amount of memory. Add updates the sketch in place and returns the
+// same pointer.
+type HyperLogLog[A any] struct {
+	precision uint8
+	registers []uint8
+	hash      func(A) uint64
+}
+
+// NewHyperLogLog creates an empty sketch for strings or byte slices with
+// 2^precision registers, where 4 <= precision <= 16. The relative error is
+// about 1.04/sqrt(2^precision). Values are hashed deterministically, so
+// sketches can be merged across processes.
+func NewHyperLogLog[A ~string | ~[]byte](precision uint8) *HyperLogLog[A] {
+	return NewHyperLogLogHash(precision, func(a A) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(a))
+		return mix64(h.Sum64())
+	})
+}
+
+// NewHyperLogLogHash creates an empty sketch that uses the given 64-bit hash
+// function.
+func NewHyperLogLogHash[A any](precision uint8, hash func(A) uint64) *HyperLogLog[A] {
+	if precision < 4 || precision > 16 {
+		panic("precision must be within [4, 16]")
+	}
+	return &HyperLogLog[A]{precision, make([]uint8, 1<<precision), hash}
+}
+
+// Add a value to the sketch.
+func (h *HyperLogLog[A]) Add(a A) *HyperLogLog[A] {
+	x := h.hash(a)
+	i := x >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1)) + 1)
+	h.registers[i] = max(h.registers[i], rank)
+	return h
+}
+
+// Merge the sketch of another partition into a new sketch. Both sketches
+// must have the same precision and hash function.
+func (h *HyperLogLog[A]) Merge(o *HyperLogLog[A]) *HyperLogLog[A] {
+	if h.precision != o.precision {
+		panic("cannot merge sketches with different precisions")
+	}
+	m := &HyperLogLog[A]{h.precision, slices.Clone(h.registers), h.hash}
+	for i, r := range o.registers {
+		m.registers[i] = max(m.registers[i], r)
+	}
+	return m
+}
+
+// Count estimates the number of distinct values.
+func (h *HyperLogLog[A]) Count() uint64 {
+	m := float64(len(h.registers))
+	var sum float64
+	var zeros = 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros += 1
+		}
+	}
+	estimate := hllAlpha(len(h.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros)) // linear counting
+	}
+	return uint64(math.Round(estimate))
+}
+
+// Spread the bits of a hash value (the finalizer of MurmurHash3), because the
+// sketch relies on the leading bits being uniformly distributed.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// A Counter is an estimated frequency of a value. The true frequency lies
+// within [Count-Err, Count].
+type Counter[A comparable] struct {
+	Value A
+	Count int
+	Err   int
+}
+
+// TopK finds the most frequent values (heavy hitters) in a stream with the
+// Space-Saving algorithm, which keeps a fixed number of counters. Add updates
+// the counters in place and returns the same pointer.
+type TopK[A comparable] struct {
+	k        int
+	counters map[A]*Counter[A]
+}
+
+// NewTopK creates an empty set of k counters.
+func NewTopK[A comparable](k int) *TopK[A] {
+	if k <= 0 {
+		panic("k must be positive")
+	}
+	return &TopK[A]{k, make(map[A]*Counter[A], k)}
+}
+
+// Add a value to the counters. If the value is not counted and all counters
+// are in use, the counter with the lowest count is taken over.
+func (t *TopK[A]) Add(a A) *TopK[A] {
+	if c, ok := t.counters[a]; ok {
+		c.Count += 1
+		return t
+	}
+	if len(t.counters) < t.k {
+		t.counters[a] = &Counter[A]{a, 1, 0}
+		return t
+	}
+	low := t.lowest()
+	delete(t.counters, low.Value)
+	t.counters[a] = &Counter[A]{a, low.Count + 1, low.Count}
+	return t
+}
+
+// Merge the counters of another partition into a new set of counters. A value
+// that is only counted in one partition may have occurred up to the lowest
+// count of the other partition, which is added to its count and error.
+func (t *TopK[A]) Merge(o *TopK[A]) *TopK[A] {
+	m := NewTopK[A](t.k)
+	tmin, omin := t.minCount(), o.minCount()
+	for a, c := range t.counters {
+		m.counters[a] = &Counter[A]{a, c.Count + omin, c.Err + omin}
+	}
+	for a, c := range o.counters {
+		if mc, ok := m.counters[a]; ok {
+			mc.Count += c.Count - omin
+			mc.Err += c.Err - omin
+		} else {
+			m.counters[a] = &Counter[A]{a, c.Count + tmin, c.Err + tmin}
+		}
+	}
+	for len(m.counters) > m.k {
+		delete(m.counters, m.lowest().Value)
+	}
+	return m
+}
+
+// Top returns the counters in order of decreasing count.
+func (t *TopK[A]) Top() []Counter[A] {
+	var cs = make([]Counter[A], 0, len(t.counters))
+	for _, c := range t.counters {
+		cs = append(cs, *c)
+	}
+	slices.SortStableFunc(cs, func(a, b Counter[A]) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Err, b.Err))
+	})
+	return cs
+}
+
+// Return the counter with the lowest count.
+func (t *TopK[A]) lowest() *Counter[A] {
+	var low *Counter[A]
+	for _, c := range t.counters {
+		if low == nil || c.Count < low.Count {
+			low = c
+		}
+	}
+	return low
+}
+
+// Return the lowest count if all counters are in use, or zero otherwise,
+// which bounds the count of any value that is not counted.
+func (t *TopK[A]) minCount() int {
+	if len(t.counters) < t.k {
+		return 0
+	}
+	return t.lowest().Count
+}
@@ -0,0 +1,205 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/basbiezemans/gofunctools/iters"
+	fsl "github.com/basbiezemans/gofunctools/slices"
+)
+
+// All accumulators implement the common interface
+var (
+	_ Accumulator[int, RunningMean[int]]        = RunningMean[int]{}
+	_ Accumulator[int, RunningVariance[int]]    = RunningVariance[int]{}
+	_ Accumulator[float64, EWMA[float64]]       = EWMA[float64]{}
+	_ Accumulator[float64, *Reservoir[float64]] = &Reservoir[float64]{}
+	_ Accumulator[string, *HyperLogLog[string]] = &HyperLogLog[string]{}
+	_ Accumulator[string, *TopK[string]]        = &TopK[string]{}
+)
+
+func TestRunningMean(t *testing.T) {
+	xs := []int{1, 2, 3, 4}
+	r := fsl.FoldLeft(RunningMean[int].Add, RunningMean[int]{}, xs)
+	if have, err := r.Mean(); err != nil || have != 2.5 || r.Count() != 4 {
+		t.Errorf("FoldLeft(RunningMean.Add, %v).Mean() = %v, %v, expected 2.5", xs, have, err)
+	}
+	var means []float64
+	for r := range iters.Scan(RunningMean[int].Add, RunningMean[int]{}, slices.Values(xs)) {
+		if m, err := r.Mean(); err == nil {
+			means = append(means, m)
+		}
+	}
+	if want := []float64{1, 1.5, 2, 2.5}; !slices.Equal(means, want) {
+		t.Errorf("Scan(RunningMean.Add, %v) = %v, expected %v", xs, means, want)
+	}
+	left := fsl.FoldLeft(RunningMean[int].Add, RunningMean[int]{}, xs[:1])
+	right := fsl.FoldLeft(RunningMean[int].Add, RunningMean[int]{}, xs[1:])
+	if merged := MergeAll(left, right, RunningMean[int]{}); merged != r {
+		t.Errorf("MergeAll(left, right) = %v, expected %v", merged, r)
+	}
+	if _, err := (RunningMean[int]{}).Mean(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("RunningMean{}.Mean() = %v, expected %v", err, ErrEmpty)
+	}
+}
+
+func TestRunningVariance(t *testing.T) {
+	xs := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	r := fsl.FoldLeft(RunningVariance[int].Add, RunningVariance[int]{}, xs)
+	if have, err := r.Variance(); err != nil || have != 4 {
+		t.Errorf("Variance = %v, %v, expected 4", have, err)
+	}
+	if have, _ := r.StdDev(); have != 2 {
+		t.Errorf("StdDev = %v, expected 2", have)
+	}
+	if have, _ := r.SampleVariance(); math.Abs(have-32.0/7) > 1e-12 {
+		t.Errorf("SampleVariance = %v, expected %v", have, 32.0/7)
+	}
+	var parts []RunningVariance[int]
+	for _, chunk := range [][]int{xs[:3], xs[3:4], {}, xs[4:]} {
+		parts = append(parts, fsl.FoldLeft(RunningVariance[int].Add, RunningVariance[int]{}, chunk))
+	}
+	merged := MergeAll(parts[0], parts[1:]...)
+	have, _ := merged.Variance()
+	mean, _ := merged.Mean()
+	if math.Abs(have-4) > 1e-12 || mean != 5 || merged.Count() != 8 {
+		t.Errorf("merged variance = %v, mean %v, count %d, expected 4, 5, 8", have, mean, merged.Count())
+	}
+	if _, err := (RunningVariance[int]{}).Add(1).SampleVariance(); !errors.Is(err, ErrTooFew) {
+		t.Errorf("SampleVariance of [1] = %v, expected %v", err, ErrTooFew)
+	}
+}
+
+func TestEWMA(t *testing.T) {
+	e := NewEWMA[float64](0.5)
+	if _, err := e.Value(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("NewEWMA(0.5).Value() = %v, expected %v", err, ErrEmpty)
+	}
+	// Bias correction makes the average of a single value equal to that value
+	if have, _ := e.Add(10).Value(); have != 10 {
+		t.Errorf("EWMA of [10] = %v, expected 10", have)
+	}
+	xs := []float64{10, 20, 30, 40}
+	full := fsl.FoldLeft(EWMA[float64].Add, e, xs)
+	// Without bias correction: 10, 15, 22.5, 31.25; weight 1-0.5^4 = 0.9375
+	want := (0.5*40 + 0.25*30 + 0.125*20 + 0.0625*10) / 0.9375
+	if have, _ := full.Value(); math.Abs(have-want) > 1e-12 {
+		t.Errorf("EWMA of %v = %v, expected %v", xs, have, want)
+	}
+	left := fsl.FoldLeft(EWMA[float64].Add, e, xs[:2])
+	right := fsl.FoldLeft(EWMA[float64].Add, e, xs[2:])
+	if have, _ := left.Merge(right).Value(); math.Abs(have-want) > 1e-12 {
+		t.Errorf("merged EWMA of %v = %v, expected %v", xs, have, want)
+	}
+}
+
+func TestReservoir(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	n := 100_000
+	r := NewReservoir[int](1000, rng)
+	for i := range n {
+		r.Add(i)
+	}
+	if len(r.Sample()) != 1000 || r.Count() != n {
+		t.Fatalf("reservoir has %d samples of %d values, expected 1000 of %d", len(r.Sample()), r.Count(), n)
+	}
+	for _, q := range []float64{0.1, 0.5, 0.9} {
+		have, err := r.Quantile(q)
+		if want := q * float64(n); err != nil || math.Abs(have-want) > 0.05*float64(n) {
+			t.Errorf("Quantile(%v) = %v, %v, expected about %v", q, have, err, want)
+		}
+	}
+	small := NewReservoir[int](1000, rng)
+	for i := range 10 {
+		small.Add(i)
+	}
+	merged := r.Merge(small)
+	if len(merged.Sample()) != 1000 || merged.Count() != n+10 {
+		t.Errorf("merged reservoir has %d samples of %d values", len(merged.Sample()), merged.Count())
+	}
+	if have, _ := merged.Quantile(0.5); math.Abs(have-float64(n)/2) > 0.05*float64(n) {
+		t.Errorf("merged Quantile(0.5) = %v, expected about %v", have, n/2)
+	}
+	if merged := small.Merge(NewReservoir[int](5, rng)); !slices.Equal(sorted(merged.Sample()), sorted(small.Sample())) {
+		t.Errorf("merging an empty reservoir changed the sample: %v", merged.Sample())
+	}
+}
+
+func TestHyperLogLog(t *testing.T) {
+	for _, n := range []int{10, 1000, 100_000} {
+		h := NewHyperLogLog[string](12)
+		for i := range 2 * n {
+			h.Add(strconv.Itoa(i % n)) // every value twice
+		}
+		have := float64(h.Count())
+		if math.Abs(have-float64(n)) > 0.05*float64(n)+1 {
+			t.Errorf("HyperLogLog count of %d distinct values = %v", n, have)
+		}
+	}
+	hash := func(i int) uint64 { return mix64(uint64(i)) }
+	a, b := NewHyperLogLogHash(12, hash), NewHyperLogLogHash(12, hash)
+	fsl.FoldLeft((*HyperLogLog[int]).Add, a, fsl.Unfold(upTo(50_000), 0))
+	fsl.FoldLeft((*HyperLogLog[int]).Add, b, fsl.Unfold(upTo(75_000), 25_000))
+	have := float64(a.Merge(b).Count())
+	if math.Abs(have-75_000) > 0.05*75_000 {
+		t.Errorf("merged HyperLogLog count = %v, expected about 75000", have)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	// "a" occurs 50 times, "b" 30 times, "c" 15 times, plus 100 singletons
+	var stream []string
+	for i := range 100 {
+		stream = append(stream, "a", "x"+strconv.Itoa(i))
+		if i%2 == 0 {
+			stream = append(stream, "a")
+		}
+		if i%10 < 3 {
+			stream = append(stream, "b")
+		}
+		if i%20 < 3 {
+			stream = append(stream, "c")
+		}
+	}
+	top := fsl.FoldLeft((*TopK[string]).Add, NewTopK[string](10), stream).Top()
+	if top[0].Value != "a" || top[0].Count-top[0].Err > 150 || top[0].Count < 150 {
+		t.Errorf("top[0] = %v, expected a with count 150", top[0])
+	}
+	if top[1].Value != "b" || top[1].Count < 30 {
+		t.Errorf("top[1] = %v, expected b with count >= 30", top[1])
+	}
+	// Add updates the counters in place, so every step of Scan is the same value
+	var steps []*TopK[string]
+	for s := range iters.Scan((*TopK[string]).Add, NewTopK[string](2), slices.Values(stream[:3])) {
+		steps = append(steps, s)
+	}
+	if len(steps) != 4 || steps[0] != steps[3] {
+		t.Errorf("Scan((*TopK).Add) yielded distinct values, expected the same pointer")
+	}
+	left := fsl.FoldLeft((*TopK[string]).Add, NewTopK[string](10), stream[:len(stream)/2])
+	right := fsl.FoldLeft((*TopK[string]).Add, NewTopK[string](10), stream[len(stream)/2:])
+	merged := MergeAll(left, right).Top()
+	if len(merged) != 10 || merged[0].Value != "a" || merged[1].Value != "b" {
+		t.Errorf("merged top = %v, expected a, b first", merged)
+	}
+	for _, c := range merged {
+		if c.Value == "a" && (c.Count < 150 || c.Count-c.Err > 150) {
+			t.Errorf("merged count of a = %v, expected bounds around 150", c)
+		}
+	}
+}
+
+func upTo(n int) func(int) (int, int, bool) {
+	return func(i int) (int, int, bool) {
+		return i, i + 1, i < n
+	}
+}
+
+func sorted(xs []int) []int {
+	slices.Sort(xs)
+	return xs
+}
@@ -0,0 +1,309 @@
+// Package stats defines numeric aggregations over slices and iterators. Each
+// aggregation has a slice variant and an iterator variant with a Seq suffix.
+// Sums use compensated (Kahan-Babuska) summation and variances use Welford's
+// algorithm, so results remain accurate for long inputs.
+package stats
+
+import (
+	"errors"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+
+	opr "github.com/basbiezemans/gofunctools/operators"
+)
+
+// ErrEmpty is returned by aggregations that are undefined for empty input.
+var ErrEmpty = errors.New("empty input")
+
+// ErrTooFew is returned by the sample variance and standard deviation, which
+// are undefined for fewer than two values.
+var ErrTooFew = errors.New("fewer than two values")
+
+// ErrPercentile is returned by Percentile if p is not within [0, 100].
+var ErrPercentile = errors.New("percentile must be between 0 and 100")
+
+// ErrNotFinite is returned by Histogram if a value is infinite or NaN.
+var ErrNotFinite = errors.New("value is infinite or NaN")
+
+// A Bin is a histogram bin. It counts the values in the half-open interval
+// [Lo, Hi), except for the last bin, which includes Hi.
+type Bin struct {
+	Lo, Hi float64
+	Count  int
+}
+
+// Sum returns the sum of a slice of numbers, or zero if the slice is empty.
+func Sum[T opr.Number](xs []T) T {
+	return SumSeq(slices.Values(xs))
+}
+
+// SumSeq returns the sum of an iterator of numbers. Floating-point numbers are
+// summed with compensation for lost low-order bits.
+func SumSeq[T opr.Number](seq iter.Seq[T]) T {
+	var sum, c T
+	for x := range seq {
+		sum, c = kahanAdd(sum, c, x)
+	}
+	return sum + c
+}
+
+// Product returns the product of a slice of numbers, or one if the slice is
+// empty.
+func Product[T opr.Number](xs []T) T {
+	return ProductSeq(slices.Values(xs))
+}
+
+// ProductSeq returns the product of an iterator of numbers.
+func ProductSeq[T opr.Number](seq iter.Seq[T]) T {
+	var prod T = 1
+	for x := range seq {
+		prod *= x
+	}
+	return prod
+}
+
+// Mean returns the arithmetic mean of a slice of numbers.
+func Mean[T opr.Number](xs []T) (float64, error) {
+	return MeanSeq(slices.Values(xs))
+}
+
+// MeanSeq returns the arithmetic mean of an iterator of numbers.
+func MeanSeq[T opr.Number](seq iter.Seq[T]) (float64, error) {
+	var sum, c float64
+	var n = 0
+	for x := range seq {
+		sum, c = kahanAdd(sum, c, float64(x))
+		n += 1
+	}
+	if n == 0 {
+		return 0, ErrEmpty
+	}
+	return (sum + c) / float64(n), nil
+}
+
+// Median returns the middle value of a slice of numbers, or the mean of the
+// two middle values if the length of the slice is even.
+func Median[T opr.Number](xs []T) (float64, error) {
+	return Percentile(50, xs)
+}
+
+// MedianSeq returns the median of an iterator of numbers. The iterator has to
+// be finite, because all values are collected.
+func MedianSeq[T opr.Number](seq iter.Seq[T]) (float64, error) {
+	return Median(slices.Collect(seq))
+}
+
+// Mode returns the most frequent value of a slice of numbers. If several
+// values are equally frequent, the smallest of them is returned.
+func Mode[T opr.Number](xs []T) (T, error) {
+	return ModeSeq(slices.Values(xs))
+}
+
+// ModeSeq returns the most frequent value of an iterator of numbers.
+func ModeSeq[T opr.Number](seq iter.Seq[T]) (T, error) {
+	var freq = make(map[T]int)
+	for x := range seq {
+		freq[x] += 1
+	}
+	if len(freq) == 0 {
+		return 0, ErrEmpty
+	}
+	var mode T
+	var count = 0
+	for _, x := range slices.Sorted(maps.Keys(freq)) {
+		if freq[x] > count {
+			mode, count = x, freq[x]
+		}
+	}
+	return mode, nil
+}
+
+// Variance returns the population variance of a slice of numbers.
+func Variance[T opr.Number](xs []T) (float64, error) {
+	return VarianceSeq(slices.Values(xs))
+}
+
+// VarianceSeq returns the population variance of an iterator of numbers.
+func VarianceSeq[T opr.Number](seq iter.Seq[T]) (float64, error) {
+	n, _, m2 := welford(seq)
+	if n == 0 {
+		return 0, ErrEmpty
+	}
+	return m2 / float64(n), nil
+}
+
+// SampleVariance returns the (unbiased) sample variance of a slice of numbers.
+// It requires at least two values, and returns ErrTooFew otherwise.
+func SampleVariance[T opr.Number](xs []T) (float64, error) {
+	return SampleVarianceSeq(slices.Values(xs))
+}
+
+// SampleVarianceSeq returns the sample variance of an iterator of numbers.
+func SampleVarianceSeq[T opr.Number](seq iter.Seq[T]) (float64, error) {
+	n, _, m2 := welford(seq)
+	if n < 2 {
+		return 0, ErrTooFew
+	}
+	return m2 / float64(n-1), nil
+}
+
+// StdDev returns the population standard deviation of a slice of numbers.
+func StdDev[T opr.Number](xs []T) (float64, error) {
+	return StdDevSeq(slices.Values(xs))
+}
+
+// StdDevSeq returns the population standard deviation of an iterator of
+// numbers.
+func StdDevSeq[T opr.Number](seq iter.Seq[T]) (float64, error) {
+	v, err := VarianceSeq(seq)
+	return math.Sqrt(v), err
+}
+
+// SampleStdDev returns the sample standard deviation of a slice of numbers.
+func SampleStdDev[T opr.Number](xs []T) (float64, error) {
+	return SampleStdDevSeq(slices.Values(xs))
+}
+
+// SampleStdDevSeq returns the sample standard deviation of an iterator of
+// numbers.
+func SampleStdDevSeq[T opr.Number](seq iter.Seq[T]) (float64, error) {
+	v, err := SampleVarianceSeq(seq)
+	return math.Sqrt(v), err
+}
+
+// Percentile returns the p-th percentile of a slice of numbers, where p lies
+// within [0, 100]. Values between two data points are linearly interpolated.
+func Percentile[T opr.Number](p float64, xs []T) (float64, error) {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, ErrPercentile
+	}
+	if len(xs) == 0 {
+		return 0, ErrEmpty
+	}
+	var ys = slices.Clone(xs)
+	slices.Sort(ys)
+	var rank = p / 100 * float64(len(ys)-1)
+	var lo = int(math.Floor(rank))
+	var hi = int(math.Ceil(rank))
+	var frac = rank - float64(lo)
+	return float64(ys[lo]) + frac*(float64(ys[hi])-float64(ys[lo])), nil
+}
+
+// PercentileSeq returns the p-th percentile of an iterator of numbers. The
+// iterator has to be finite, because all values are collected.
+func PercentileSeq[T opr.Number](p float64, seq iter.Seq[T]) (float64, error) {
+	return Percentile(p, slices.Collect(seq))
+}
+
+// MinMax returns the smallest and the largest value of a slice of numbers.
+func MinMax[T opr.Number](xs []T) (T, T, error) {
+	return MinMaxSeq(slices.Values(xs))
+}
+
+// MinMaxSeq returns the smallest and the largest value of an iterator of
+// numbers.
+func MinMaxSeq[T opr.Number](seq iter.Seq[T]) (T, T, error) {
+	var lo, hi T
+	var empty = true
+	for x := range seq {
+		if empty {
+			lo, hi, empty = x, x, false
+		}
+		lo, hi = min(lo, x), max(hi, x)
+	}
+	if empty {
+		return lo, hi, ErrEmpty
+	}
+	return lo, hi, nil
+}
+
+// Histogram divides the range of a slice of numbers into n bins of equal width
+// and counts the values in each bin. If all values are equal, the bins have
+// zero width and all values are counted in the last bin. Infinite and NaN
+// values have no bin, so they are rejected with ErrNotFinite.
+func Histogram[T opr.Number](n int, xs []T) ([]Bin, error) {
+	if n <= 0 {
+		panic("number of bins must be positive")
+	}
+	for _, x := range xs {
+		if math.IsInf(float64(x), 0) || math.IsNaN(float64(x)) {
+			return nil, ErrNotFinite
+		}
+	}
+	lo, hi, err := MinMax(xs)
+	if err != nil {
+		return nil, err
+	}
+	// The range hi - lo may overflow, so it is computed from halves, and the
+	// bin bounds are interpolated between lo and hi
+	var flo, fhi = float64(lo), float64(hi)
+	var span = fhi/2 - flo/2
+	var bins = make([]Bin, n)
+	for i := range bins {
+		bins[i].Lo = lerp(flo, fhi, float64(i)/float64(n))
+		bins[i].Hi = lerp(flo, fhi, float64(i+1)/float64(n))
+	}
+	bins[n-1].Hi = fhi
+	for _, x := range xs {
+		var i = n - 1
+		if span > 0 {
+			i = max(0, min(int((float64(x)/2-flo/2)/span*float64(n)), n-1))
+		}
+		bins[i].Count += 1
+	}
+	return bins, nil
+}
+
+// HistogramSeq divides the range of an iterator of numbers into n bins of
+// equal width. The iterator has to be finite, because all values are
+// collected.
+func HistogramSeq[T opr.Number](n int, seq iter.Seq[T]) ([]Bin, error) {
+	return Histogram(n, slices.Collect(seq))
+}
+
+// Interpolate linearly between lo and hi, without computing hi - lo.
+func lerp(lo, hi, t float64) float64 {
+	return lo*(1-t) + hi*t
+}
+
+// Add x to a compensated sum, returning the new sum and compensation. This is
+// the Kahan-Babuska (Neumaier) variant, which also handles values that are
+// larger than the running sum. Once the sum is infinite or NaN, the
+// compensation is left alone, because it would become Inf - Inf = NaN.
+func kahanAdd[T opr.Number](sum, c, x T) (T, T) {
+	var t = sum + x
+	if t-t != 0 {
+		// t is ±Inf or NaN; t-t is always 0 for finite numbers and integers
+		return t, c
+	}
+	if abs(sum) >= abs(x) {
+		c += (sum - t) + x
+	} else {
+		c += (x - t) + sum
+	}
+	return t, c
+}
+
+// Compute the count, mean and sum of squared deviations of an iterator in a
+// single pass with Welford's algorithm.
+func welford[T opr.Number](seq iter.Seq[T]) (int, float64, float64) {
+	var n = 0
+	var mean, m2 float64
+	for v := range seq {
+		x := float64(v)
+		n += 1
+		delta := x - mean
+		mean += delta / float64(n)
+		m2 += delta * (x - mean)
+	}
+	return n, mean, m2
+}
+
+func abs[T opr.Number](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
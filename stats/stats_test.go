@@ -0,0 +1,192 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	if have := Sum([]int{1, 2, 3, 4}); have != 10 {
+		t.Errorf("Sum([1 2 3 4]) = %d, expected 10", have)
+	}
+	if have := Sum([]float64{}); have != 0 {
+		t.Errorf("Sum([]) = %v, expected 0", have)
+	}
+	// Naive summation loses the small values entirely
+	xs := []float64{1e16, 1, 1, 1, 1, -1e16}
+	if have := Sum(xs); have != 4 {
+		t.Errorf("Sum(%v) = %v, expected 4", xs, have)
+	}
+	inf := math.Inf(1)
+	testcases := map[string]struct {
+		input  []float64
+		expect float64
+	}{
+		"+Inf":     {[]float64{1, inf}, inf},
+		"+Inf+1":   {[]float64{inf, 1, 2}, inf},
+		"-Inf":     {[]float64{1e308, -inf, 1e308}, -inf},
+		"overflow": {[]float64{math.MaxFloat64, math.MaxFloat64}, inf},
+		"Inf-Inf":  {[]float64{inf, -inf}, math.NaN()},
+		"NaN":      {[]float64{1, math.NaN(), 2}, math.NaN()},
+	}
+	for name, test := range testcases {
+		if have := Sum(test.input); !sameFloat(have, test.expect) {
+			t.Errorf("%s: Sum(%v) = %v, expected %v", name, test.input, have, test.expect)
+		}
+	}
+	n := 1_000_000
+	tenths := slices.Repeat([]float64{0.1}, n)
+	if have := SumSeq(slices.Values(tenths)); have != 100_000 {
+		t.Errorf("SumSeq(0.1 x %d) = %v, expected 100000", n, have)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	if have := Product([]int{1, 2, 3, 4}); have != 24 {
+		t.Errorf("Product([1 2 3 4]) = %d, expected 24", have)
+	}
+	if have := ProductSeq(slices.Values([]float64{})); have != 1 {
+		t.Errorf("ProductSeq([]) = %v, expected 1", have)
+	}
+}
+
+func TestMean(t *testing.T) {
+	if have, err := Mean([]int{1, 2, 3, 4}); err != nil || have != 2.5 {
+		t.Errorf("Mean([1 2 3 4]) = %v, %v, expected 2.5, <nil>", have, err)
+	}
+	if have, _ := Mean([]float64{1, math.Inf(1), 3}); !math.IsInf(have, 1) {
+		t.Errorf("Mean([1 +Inf 3]) = %v, expected +Inf", have)
+	}
+	if have, _ := Mean([]float64{1, math.NaN()}); !math.IsNaN(have) {
+		t.Errorf("Mean([1 NaN]) = %v, expected NaN", have)
+	}
+	if _, err := MeanSeq(slices.Values([]int{})); !errors.Is(err, ErrEmpty) {
+		t.Errorf("MeanSeq([]) = %v, expected %v", err, ErrEmpty)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	testcases := map[string]struct {
+		input  []int
+		expect float64
+	}{
+		"odd":  {[]int{5, 1, 3}, 3},
+		"even": {[]int{4, 1, 3, 2}, 2.5},
+		"one":  {[]int{7}, 7},
+	}
+	for name, test := range testcases {
+		if have, err := Median(test.input); err != nil || have != test.expect {
+			t.Errorf("%s: Median(%v) = %v, %v, expected %v", name, test.input, have, err, test.expect)
+		}
+	}
+	input := []int{5, 1, 3}
+	if have, _ := MedianSeq(slices.Values(input)); have != 3 || input[0] != 5 {
+		t.Errorf("MedianSeq(%v) = %v, expected 3 and input unchanged", input, have)
+	}
+	if _, err := Median([]int{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Median([]) = %v, expected %v", err, ErrEmpty)
+	}
+}
+
+func TestMode(t *testing.T) {
+	if have, err := Mode([]int{3, 1, 3, 2, 1}); err != nil || have != 1 {
+		t.Errorf("Mode([3 1 3 2 1]) = %v, %v, expected 1, <nil>", have, err)
+	}
+	if have, _ := ModeSeq(slices.Values([]float64{2.5, 0.5, 2.5})); have != 2.5 {
+		t.Errorf("ModeSeq([2.5 0.5 2.5]) = %v, expected 2.5", have)
+	}
+	if _, err := Mode([]int{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Mode([]) = %v, expected %v", err, ErrEmpty)
+	}
+}
+
+func TestVariance(t *testing.T) {
+	xs := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	if have, err := Variance(xs); err != nil || have != 4 {
+		t.Errorf("Variance(%v) = %v, %v, expected 4, <nil>", xs, have, err)
+	}
+	if have, _ := StdDev(xs); have != 2 {
+		t.Errorf("StdDev(%v) = %v, expected 2", xs, have)
+	}
+	if have, _ := SampleVariance(xs); math.Abs(have-32.0/7) > 1e-12 {
+		t.Errorf("SampleVariance(%v) = %v, expected %v", xs, have, 32.0/7)
+	}
+	if have, _ := SampleStdDev(xs); math.Abs(have-math.Sqrt(32.0/7)) > 1e-12 {
+		t.Errorf("SampleStdDev(%v) = %v, expected %v", xs, have, math.Sqrt(32.0/7))
+	}
+	// A large offset makes the naive sum-of-squares formula lose all precision
+	ys := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	if have, _ := VarianceSeq(slices.Values(ys)); have != 22.5 {
+		t.Errorf("VarianceSeq(%v) = %v, expected 22.5", ys, have)
+	}
+	if have, _ := SampleStdDevSeq(slices.Values(xs)); math.Abs(have-math.Sqrt(32.0/7)) > 1e-12 {
+		t.Errorf("SampleStdDevSeq(%v) = %v, expected %v", xs, have, math.Sqrt(32.0/7))
+	}
+	if _, err := SampleVariance([]int{1}); !errors.Is(err, ErrTooFew) {
+		t.Errorf("SampleVariance([1]) = %v, expected %v", err, ErrTooFew)
+	}
+	if _, err := SampleStdDev([]int{}); !errors.Is(err, ErrTooFew) {
+		t.Errorf("SampleStdDev([]) = %v, expected %v", err, ErrTooFew)
+	}
+	if _, err := StdDevSeq(slices.Values([]int{})); !errors.Is(err, ErrEmpty) {
+		t.Errorf("StdDevSeq([]) = %v, expected %v", err, ErrEmpty)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	xs := []int{15, 20, 35, 40, 50}
+	testcases := map[float64]float64{0: 15, 25: 20, 40: 29, 50: 35, 100: 50}
+	for p, expect := range testcases {
+		if have, err := Percentile(p, xs); err != nil || math.Abs(have-expect) > 1e-12 {
+			t.Errorf("Percentile(%v, %v) = %v, %v, expected %v", p, xs, have, err, expect)
+		}
+	}
+	if have, _ := PercentileSeq(90, slices.Values(xs)); have != 46 {
+		t.Errorf("PercentileSeq(90, %v) = %v, expected 46", xs, have)
+	}
+	if _, err := Percentile(101, xs); !errors.Is(err, ErrPercentile) {
+		t.Errorf("Percentile(101, %v) = %v, expected %v", xs, err, ErrPercentile)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	if lo, hi, err := MinMax([]int{3, -1, 4, 1, 5}); err != nil || lo != -1 || hi != 5 {
+		t.Errorf("MinMax([3 -1 4 1 5]) = %v, %v, %v, expected -1, 5, <nil>", lo, hi, err)
+	}
+	if _, _, err := MinMaxSeq(slices.Values([]int{})); !errors.Is(err, ErrEmpty) {
+		t.Errorf("MinMaxSeq([]) = %v, expected %v", err, ErrEmpty)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	xs := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}
+	expect := []Bin{{0, 2.5, 3}, {2.5, 5, 2}, {5, 7.5, 3}, {7.5, 10, 2}}
+	if have, err := Histogram(4, xs); err != nil || !reflect.DeepEqual(have, expect) {
+		t.Errorf("Histogram(4, %v) = %v, %v, expected %v", xs, have, err, expect)
+	}
+	expect = []Bin{{3, 3, 0}, {3, 3, 2}}
+	if have, _ := HistogramSeq(2, slices.Values([]int{3, 3})); !reflect.DeepEqual(have, expect) {
+		t.Errorf("HistogramSeq(2, [3 3]) = %v, expected %v", have, expect)
+	}
+	if _, err := Histogram(3, []float64{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Histogram(3, []) = %v, expected %v", err, ErrEmpty)
+	}
+	// The range of these values overflows a float64
+	expect = []Bin{{-1e308, 0, 1}, {0, 1e308, 1}}
+	if have, err := Histogram(2, []float64{-1e308, 1e308}); err != nil || !reflect.DeepEqual(have, expect) {
+		t.Errorf("Histogram(2, [-1e308 1e308]) = %v, %v, expected %v", have, err, expect)
+	}
+	for _, x := range []float64{math.Inf(-1), math.Inf(1), math.NaN()} {
+		if _, err := Histogram(3, []float64{x, 0, 1}); !errors.Is(err, ErrNotFinite) {
+			t.Errorf("Histogram(3, [%v 0 1]) = %v, expected %v", x, err, ErrNotFinite)
+		}
+	}
+}
+
+// Compare floats, treating NaN as equal to NaN.
+func sameFloat(x, y float64) bool {
+	return x == y || (math.IsNaN(x) && math.IsNaN(y))
+}
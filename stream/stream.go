@@ -0,0 +1,198 @@
+// Package stream defines a fluent wrapper around iterators. Operations that
+// preserve the element type are methods, so they can be chained from left to
+// right:
+//
+//	stream.Of(xs...).Filter(even).Sorted(cmp.Compare).Take(3).Collect()
+//
+// Go methods cannot have type parameters, so operations that change the
+// element type, like Map, are free functions.
+package stream
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/basbiezemans/gofunctools/iters"
+)
+
+// A Stream is a lazy sequence of values. Like the iterator it wraps, a Stream
+// is evaluated anew by each terminal operation.
+type Stream[A any] struct {
+	seq iter.Seq[A]
+}
+
+// Create a Stream of the given values. The values are copied, so the Stream
+// does not change if the slice passed as xs... changes.
+func Of[A any](xs ...A) Stream[A] {
+	return Stream[A]{slices.Values(slices.Clone(xs))}
+}
+
+// Create a Stream from an iterator.
+func FromSeq[A any](seq iter.Seq[A]) Stream[A] {
+	return Stream[A]{seq}
+}
+
+// Map applies a unary function to each element of a Stream.
+func Map[A, B any](fn func(A) B, s Stream[A]) Stream[B] {
+	return Stream[B]{iters.Map(fn, s.seq)}
+}
+
+// FlatMap applies a function, which returns a Stream, to each element of a
+// Stream and concatenates the results.
+func FlatMap[A, B any](fn func(A) Stream[B], s Stream[A]) Stream[B] {
+	return Stream[B]{iters.FlatMap(func(a A) iter.Seq[B] { return fn(a).seq }, s.seq)}
+}
+
+// Fold reduces a Stream from left to right, starting with an initial value.
+func Fold[A, B any](fn func(B, A) B, initValue B, s Stream[A]) B {
+	var acc = initValue
+	for a := range s.seq {
+		acc = fn(acc, a)
+	}
+	return acc
+}
+
+// DistinctBy removes the elements whose key has been seen before.
+func DistinctBy[A any, K comparable](key func(A) K, s Stream[A]) Stream[A] {
+	return Stream[A]{func(yield func(A) bool) {
+		var seen = make(map[K]struct{})
+		for a := range s.seq {
+			k := key(a)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(a) {
+				return
+			}
+		}
+	}}
+}
+
+// Seq returns the iterator of a Stream.
+func (s Stream[A]) Seq() iter.Seq[A] {
+	return s.seq
+}
+
+// Filter keeps the elements that satisfy a predicate.
+func (s Stream[A]) Filter(fn func(A) bool) Stream[A] {
+	return Stream[A]{iters.Filter(fn, s.seq)}
+}
+
+// Take keeps the first n elements.
+func (s Stream[A]) Take(n int) Stream[A] {
+	return Stream[A]{iters.Take(n, s.seq)}
+}
+
+// Drop removes the first n elements.
+func (s Stream[A]) Drop(n int) Stream[A] {
+	return Stream[A]{iters.Drop(n, s.seq)}
+}
+
+// TakeWhile keeps the elements up to the first one that does not satisfy a
+// predicate.
+func (s Stream[A]) TakeWhile(fn func(A) bool) Stream[A] {
+	return Stream[A]{iters.TakeWhile(fn, s.seq)}
+}
+
+// DropWhile removes the elements up to the first one that does not satisfy a
+// predicate.
+func (s Stream[A]) DropWhile(fn func(A) bool) Stream[A] {
+	return Stream[A]{iters.DropWhile(fn, s.seq)}
+}
+
+// Distinct removes the elements that have been seen before. The elements
+// must be comparable at run time, otherwise Distinct panics; use DistinctBy
+// with a comparable key for other element types.
+func (s Stream[A]) Distinct() Stream[A] {
+	return DistinctBy(func(a A) any { return a }, s)
+}
+
+// Sorted sorts the elements with a comparison function, like cmp.Compare.
+// The sort is stable. All elements are collected before the first one is
+// passed on, so the Stream has to be finite.
+func (s Stream[A]) Sorted(cmp func(A, A) int) Stream[A] {
+	return Stream[A]{func(yield func(A) bool) {
+		var xs = slices.Collect(s.seq)
+		slices.SortStableFunc(xs, cmp)
+		for _, x := range xs {
+			if !yield(x) {
+				return
+			}
+		}
+	}}
+}
+
+// Peek calls a function for each element as it passes through the Stream,
+// e.g. for logging.
+func (s Stream[A]) Peek(fn func(A)) Stream[A] {
+	return Stream[A]{iters.Map(func(a A) A { fn(a); return a }, s.seq)}
+}
+
+// Collect the elements of a Stream in a slice.
+func (s Stream[A]) Collect() []A {
+	return slices.Collect(s.seq)
+}
+
+// Count the elements of a Stream.
+func (s Stream[A]) Count() int {
+	var n = 0
+	for range s.seq {
+		n += 1
+	}
+	return n
+}
+
+// Reduce the elements of a Stream from left to right with a binary function.
+// The result is false if the Stream is empty.
+func (s Stream[A]) Reduce(fn func(A, A) A) (A, bool) {
+	var acc A
+	var ok = false
+	for a := range s.seq {
+		if !ok {
+			acc, ok = a, true
+			continue
+		}
+		acc = fn(acc, a)
+	}
+	return acc, ok
+}
+
+// ForEach calls a function for each element of a Stream.
+func (s Stream[A]) ForEach(fn func(A)) {
+	for a := range s.seq {
+		fn(a)
+	}
+}
+
+// AnyMatch determines if any element satisfies a predicate. It stops at the
+// first element that does.
+func (s Stream[A]) AnyMatch(fn func(A) bool) bool {
+	for a := range s.seq {
+		if fn(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllMatch determines if all elements satisfy a predicate. It stops at the
+// first element that does not.
+func (s Stream[A]) AllMatch(fn func(A) bool) bool {
+	for a := range s.seq {
+		if !fn(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// NoneMatch determines if no element satisfies a predicate.
+func (s Stream[A]) NoneMatch(fn func(A) bool) bool {
+	return !s.AnyMatch(fn)
+}
+
+// First returns the first element of a Stream, or false if it is empty.
+func (s Stream[A]) First() (A, bool) {
+	return iters.First(s.seq)
+}
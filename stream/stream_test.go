@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"cmp"
+	"reflect"
+	"strings"
+	"testing"
+
+	opr "github.com/basbiezemans/gofunctools/operators"
+)
+
+func naturals(yield func(int) bool) {
+	for i := 0; yield(i); i++ {
+	}
+}
+
+func TestChain(t *testing.T) {
+	have := Of(5, 3, 8, 3, 1, 8, 9, 2).
+		Distinct().
+		Filter(opr.GreaterThanN(1)).
+		Sorted(cmp.Compare[int]).
+		Drop(1).
+		Take(3).
+		Collect()
+	if expect := []int{3, 5, 8}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("chain = %v, expected %v", have, expect)
+	}
+}
+
+func TestInfinite(t *testing.T) {
+	var peeked []int
+	squares := Map(func(n int) int { return n * n }, FromSeq(naturals))
+	have := squares.Peek(func(n int) { peeked = append(peeked, n) }).
+		DropWhile(opr.LessThanN(10)).
+		TakeWhile(opr.LessThanN(50)).
+		Collect()
+	if expect := []int{16, 25, 36, 49}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("squares between 10 and 50 = %v, expected %v", have, expect)
+	}
+	if expect := []int{0, 1, 4, 9, 16, 25, 36, 49, 64}; !reflect.DeepEqual(peeked, expect) {
+		t.Errorf("peeked = %v, expected %v", peeked, expect)
+	}
+	if !FromSeq(naturals).AnyMatch(opr.EqualN(1000)) {
+		t.Errorf("AnyMatch(== 1000) on naturals = false, expected true")
+	}
+	if FromSeq(naturals).AllMatch(opr.LessThanN(1000)) {
+		t.Errorf("AllMatch(< 1000) on naturals = true, expected false")
+	}
+	if x, ok := FromSeq(naturals).Drop(7).First(); !ok || x != 7 {
+		t.Errorf("First() = %v, %v, expected 7, true", x, ok)
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	if n := s.Count(); n != 4 {
+		t.Errorf("Count() = %d, expected 4", n)
+	}
+	if sum, ok := s.Reduce(opr.Add); !ok || sum != 10 {
+		t.Errorf("Reduce(Add) = %v, %v, expected 10, true", sum, ok)
+	}
+	if _, ok := Of[int]().Reduce(opr.Add); ok {
+		t.Errorf("Reduce on empty stream = true, expected false")
+	}
+	var sb strings.Builder
+	s.ForEach(func(n int) { sb.WriteByte(byte('0' + n)) })
+	if sb.String() != "1234" {
+		t.Errorf("ForEach wrote %q, expected \"1234\"", sb.String())
+	}
+	if !s.NoneMatch(opr.GreaterThanN(4)) {
+		t.Errorf("NoneMatch(> 4) = false, expected true")
+	}
+	words := Fold(func(acc string, n int) string { return acc + strings.Repeat("*", n) }, "", s.Take(2))
+	if words != "***" {
+		t.Errorf("Fold = %q, expected \"***\"", words)
+	}
+	xs := []int{1, 2}
+	s = Of(xs...)
+	xs[0] = 0 // the Stream must not share memory with xs
+	if have := s.Collect(); !reflect.DeepEqual(have, []int{1, 2}) {
+		t.Errorf("Of(xs...).Collect() = %v after changing xs, expected [1 2]", have)
+	}
+}
+
+func TestMapFlatMap(t *testing.T) {
+	words := Of("go", "fun", "go", "tools")
+	have := FlatMap(func(w string) Stream[string] { return Of(strings.Split(w, "")...) },
+		DistinctBy(strings.ToUpper, words)).Collect()
+	if expect := strings.Split("gofuntools", ""); !reflect.DeepEqual(have, expect) {
+		t.Errorf("FlatMap = %v, expected %v", have, expect)
+	}
+	lengths := Map(func(w string) int { return len(w) }, words).Sorted(func(a, b int) int { return b - a })
+	if have := lengths.Collect(); !reflect.DeepEqual(have, []int{5, 3, 2, 2}) {
+		t.Errorf("lengths sorted descending = %v, expected [5 3 2 2]", have)
+	}
+	// A Stream can be evaluated more than once
+	if n := lengths.Count(); n != 4 {
+		t.Errorf("second evaluation counted %d elements, expected 4", n)
+	}
+}
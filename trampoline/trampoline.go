@@ -0,0 +1,88 @@
+// Package trampoline defines a trampoline type, which turns recursive
+// computations into a sequence of steps that run in constant stack space.
+package trampoline
+
+// A Trampoline is a computation that eventually produces a value of type A.
+// It is built with Done, More and FlatMap, and evaluated with Run. The zero
+// Trampoline is a finished computation that produces the zero value of A.
+type Trampoline[A any] struct {
+	step step
+}
+
+// A step is one of done, more or flatMap. Steps are untyped so that FlatMap
+// can chain computations that produce values of different types.
+type step interface{}
+
+type done struct {
+	value any
+}
+
+type more struct {
+	thunk func() step
+}
+
+type flatMap struct {
+	sub step
+	fn  func(any) step
+}
+
+// Done creates a computation that has finished with the given value.
+func Done[A any](a A) Trampoline[A] {
+	return Trampoline[A]{done{a}}
+}
+
+// More suspends a computation. The function is called by Run, instead of
+// being called recursively.
+func More[A any](fn func() Trampoline[A]) Trampoline[A] {
+	return Trampoline[A]{more{func() step {
+		return fn().step
+	}}}
+}
+
+// FlatMap chains a computation to the result of another computation.
+func FlatMap[A, B any](fn func(A) Trampoline[B], t Trampoline[A]) Trampoline[B] {
+	return Trampoline[B]{flatMap{t.step, func(x any) step {
+		return fn(cast[A](x)).step
+	}}}
+}
+
+// Map applies a unary function to the result of a computation.
+func Map[A, B any](fn func(A) B, t Trampoline[A]) Trampoline[B] {
+	return FlatMap(func(x A) Trampoline[B] {
+		return Done(fn(x))
+	}, t)
+}
+
+// Run evaluates a computation step by step in constant stack space and
+// returns its result. Pending continuations are kept on the heap.
+func Run[A any](t Trampoline[A]) A {
+	var current = t.step
+	var stack []func(any) step
+	for {
+		switch s := current.(type) {
+		case done:
+			if len(stack) == 0 {
+				return cast[A](s.value)
+			}
+			n := len(stack) - 1
+			fn := stack[n]
+			stack = stack[:n]
+			current = fn(s.value)
+		case more:
+			current = s.thunk()
+		case flatMap:
+			stack = append(stack, s.fn)
+			current = s.sub
+		case nil:
+			// The step of a zero Trampoline
+			current = done{}
+		}
+	}
+}
+
+// Convert an untyped value back to its type. A nil interface value is
+// converted to the zero value.
+func cast[A any](x any) A {
+	v, _ := x.(A)
+	return v
+}
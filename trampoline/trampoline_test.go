@@ -0,0 +1,84 @@
+package trampoline
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDone(t *testing.T) {
+	if have := Run(Done(42)); have != 42 {
+		t.Errorf("Run(Done(42)) = %d, expected 42", have)
+	}
+	if have := Run(Done[error](nil)); have != nil {
+		t.Errorf("Run(Done(nil)) = %v, expected <nil>", have)
+	}
+	if have := Run(Trampoline[int]{}); have != 0 {
+		t.Errorf("Run(Trampoline{}) = %d, expected 0", have)
+	}
+	zero := func(int) Trampoline[string] { return Trampoline[string]{} }
+	if have := Run(FlatMap(zero, Trampoline[int]{})); have != "" {
+		t.Errorf("Run(FlatMap(zero, Trampoline{})) = %q, expected \"\"", have)
+	}
+}
+
+func TestMap(t *testing.T) {
+	have := Run(Map(strconv.Itoa, Done(42)))
+	if have != "42" {
+		t.Errorf("Run(Map(Itoa, Done(42))) = %q, expected \"42\"", have)
+	}
+}
+
+func TestMutualRecursion(t *testing.T) {
+	var isEven, isOdd func(int) Trampoline[bool]
+	isEven = func(n int) Trampoline[bool] {
+		if n == 0 {
+			return Done(true)
+		}
+		return More(func() Trampoline[bool] { return isOdd(n - 1) })
+	}
+	isOdd = func(n int) Trampoline[bool] {
+		if n == 0 {
+			return Done(false)
+		}
+		return More(func() Trampoline[bool] { return isEven(n - 1) })
+	}
+	n := 5_000_000
+	if have := Run(isEven(n)); !have {
+		t.Errorf("Run(isEven(%d)) = %t, expected true", n, have)
+	}
+	if have := Run(isOdd(n)); have {
+		t.Errorf("Run(isOdd(%d)) = %t, expected false", n, have)
+	}
+}
+
+func TestNonTailRecursion(t *testing.T) {
+	var sum func(int) Trampoline[int]
+	sum = func(n int) Trampoline[int] {
+		if n == 0 {
+			return Done(0)
+		}
+		rest := More(func() Trampoline[int] { return sum(n - 1) })
+		return FlatMap(func(acc int) Trampoline[int] {
+			return Done(n + acc)
+		}, rest)
+	}
+	n := 2_000_000
+	want := n * (n + 1) / 2
+	if have := Run(sum(n)); have != want {
+		t.Errorf("Run(sum(%d)) = %d, expected %d", n, have, want)
+	}
+}
+
+func TestLeftNestedFlatMap(t *testing.T) {
+	inc := func(x int) Trampoline[int] {
+		return Done(x + 1)
+	}
+	n := 1_000_000
+	tr := Done(0)
+	for range n {
+		tr = FlatMap(inc, tr)
+	}
+	if have := Run(tr); have != n {
+		t.Errorf("Run(FlatMap(inc, ...)) = %d, expected %d", have, n)
+	}
+}
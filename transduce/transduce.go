@@ -0,0 +1,215 @@
+// Package transduce defines transducers: transformations of reducers that are
+// independent of the source and destination of the data. A pipeline like
+// Compose(Map(f), Filter(p)) is written once and can be applied to a slice, an
+// iterator or a channel. Each element passes through the whole pipeline before
+// the next one is read, so no intermediate collections are allocated.
+package transduce
+
+import (
+	"iter"
+	"slices"
+)
+
+// A Reducer consumes one value at a time. It returns false to stop the
+// reduction early, e.g. once Take has seen enough values. A nil Reducer is
+// done before it has seen any value, e.g. the reducer of Take(0); the drivers
+// check for it before they read from the source.
+type Reducer[A any] func(A) bool
+
+// A Transducer transforms a reducer of B values into a reducer of A values.
+// Stateful transducers, like Take and Dedupe, create fresh state each time
+// they are applied, so a transducer can be reused for several reductions.
+// Applied to a nil Reducer, the transducers of this package return nil.
+type Transducer[A, B any] func(Reducer[B]) Reducer[A]
+
+// Create a transducer that passes on a nil Reducer, which is done.
+func transducer[A, B any](fn func(Reducer[B]) Reducer[A]) Transducer[A, B] {
+	return func(next Reducer[B]) Reducer[A] {
+		if next == nil {
+			return nil
+		}
+		return fn(next)
+	}
+}
+
+// Compose two transducers. Values flow from left to right: the values are
+// transformed by xf1 first and by xf2 next. Note that this is the opposite
+// order of function composition with gofunctools.Compose.
+func Compose[A, B, C any](xf1 Transducer[A, B], xf2 Transducer[B, C]) Transducer[A, C] {
+	return func(next Reducer[C]) Reducer[A] {
+		return xf1(xf2(next))
+	}
+}
+
+// Compose three transducers, from left to right.
+func Compose3[A, B, C, D any](xf1 Transducer[A, B], xf2 Transducer[B, C], xf3 Transducer[C, D]) Transducer[A, D] {
+	return Compose(Compose(xf1, xf2), xf3)
+}
+
+// Map applies a unary function to each value.
+func Map[A, B any](fn func(A) B) Transducer[A, B] {
+	return transducer(func(next Reducer[B]) Reducer[A] {
+		return func(a A) bool {
+			return next(fn(a))
+		}
+	})
+}
+
+// Filter passes on the values that satisfy a predicate.
+func Filter[A any](fn func(A) bool) Transducer[A, A] {
+	return transducer(func(next Reducer[A]) Reducer[A] {
+		return func(a A) bool {
+			return !fn(a) || next(a)
+		}
+	})
+}
+
+// Take passes on the first n values and stops the reduction after the n-th
+// value. If n <= 0, the reduction stops before the first value is read.
+func Take[A any](n int) Transducer[A, A] {
+	if n <= 0 {
+		return func(Reducer[A]) Reducer[A] {
+			return nil
+		}
+	}
+	return transducer(func(next Reducer[A]) Reducer[A] {
+		var i = 0
+		return func(a A) bool {
+			if i >= n {
+				return false
+			}
+			i += 1
+			return next(a) && i < n
+		}
+	})
+}
+
+// TakeWhile passes on values as long as they satisfy a predicate, and stops
+// the reduction at the first value that does not.
+func TakeWhile[A any](fn func(A) bool) Transducer[A, A] {
+	return transducer(func(next Reducer[A]) Reducer[A] {
+		return func(a A) bool {
+			return fn(a) && next(a)
+		}
+	})
+}
+
+// Drop skips the first n values.
+func Drop[A any](n int) Transducer[A, A] {
+	return transducer(func(next Reducer[A]) Reducer[A] {
+		var i = 0
+		return func(a A) bool {
+			if i < n {
+				i += 1
+				return true
+			}
+			return next(a)
+		}
+	})
+}
+
+// Dedupe removes consecutive duplicates.
+func Dedupe[A comparable]() Transducer[A, A] {
+	return transducer(func(next Reducer[A]) Reducer[A] {
+		var prev A
+		var seen = false
+		return func(a A) bool {
+			if seen && a == prev {
+				return true
+			}
+			prev, seen = a, true
+			return next(a)
+		}
+	})
+}
+
+// Window passes on sliding windows of n consecutive values. Each window is a
+// new slice. If there are fewer than n values, there are no windows.
+func Window[A any](n int) Transducer[A, []A] {
+	if n <= 0 {
+		panic("window size must be positive")
+	}
+	return transducer(func(next Reducer[[]A]) Reducer[A] {
+		var buf = make([]A, 0, n)
+		return func(a A) bool {
+			if len(buf) == n {
+				copy(buf, buf[1:])
+				buf = buf[:n-1]
+			}
+			buf = append(buf, a)
+			return len(buf) < n || next(slices.Clone(buf))
+		}
+	})
+}
+
+// Transduce applies a transducer to a slice and reduces the results from left
+// to right with a reducing function.
+func Transduce[A, B, S any](xf Transducer[A, B], fn func(S, B) S, init S, xs []A) S {
+	return TransduceSeq(xf, fn, init, slices.Values(xs))
+}
+
+// TransduceSeq applies a transducer to an iterator and reduces the results
+// with a reducing function. The iterator may be infinite if the transducer
+// stops the reduction, e.g. with Take.
+func TransduceSeq[A, B, S any](xf Transducer[A, B], fn func(S, B) S, init S, seq iter.Seq[A]) S {
+	var acc = init
+	var step = xf(func(b B) bool {
+		acc = fn(acc, b)
+		return true
+	})
+	if step == nil {
+		return acc
+	}
+	for a := range seq {
+		if !step(a) {
+			break
+		}
+	}
+	return acc
+}
+
+// TransduceChan applies a transducer to the values received from a channel
+// and reduces the results with a reducing function. It returns when the
+// channel is closed, or when the transducer stops the reduction, in which
+// case the remaining values are left in the channel.
+func TransduceChan[A, B, S any](xf Transducer[A, B], fn func(S, B) S, init S, ch <-chan A) S {
+	var acc = init
+	var step = xf(func(b B) bool {
+		acc = fn(acc, b)
+		return true
+	})
+	if step == nil {
+		return acc
+	}
+	for a := range ch {
+		if !step(a) {
+			break
+		}
+	}
+	return acc
+}
+
+// Collect applies a transducer to a slice and collects the results in a new
+// slice.
+func Collect[A, B any](xf Transducer[A, B], xs []A) []B {
+	return Transduce(xf, appendOne[B], nil, xs)
+}
+
+// Seq applies a transducer lazily to an iterator.
+func Seq[A, B any](xf Transducer[A, B], seq iter.Seq[A]) iter.Seq[B] {
+	return func(yield func(B) bool) {
+		var step = xf(Reducer[B](yield))
+		if step == nil {
+			return
+		}
+		for a := range seq {
+			if !step(a) {
+				return
+			}
+		}
+	}
+}
+
+func appendOne[A any](xs []A, x A) []A {
+	return append(xs, x)
+}
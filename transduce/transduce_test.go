@@ -0,0 +1,138 @@
+package transduce
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/basbiezemans/gofunctools/iters"
+	opr "github.com/basbiezemans/gofunctools/operators"
+)
+
+func naturals(yield func(int) bool) {
+	for i := 0; yield(i); i++ {
+	}
+}
+
+func TestCollect(t *testing.T) {
+	data := []int{1, 2, 2, 3, 3, 3, 4, 5, 6}
+	testcases := map[string]struct {
+		xf     Transducer[int, int]
+		expect []int
+	}{
+		"Map":       {Map(opr.MultiplyN(10)), []int{10, 20, 20, 30, 30, 30, 40, 50, 60}},
+		"Filter":    {Filter(opr.Odd[int]), []int{1, 3, 3, 3, 5}},
+		"Take":      {Take[int](4), []int{1, 2, 2, 3}},
+		"Take(0)":   {Take[int](0), nil},
+		"TakeWhile": {TakeWhile(opr.LessThanN(3)), []int{1, 2, 2}},
+		"Drop":      {Drop[int](6), []int{4, 5, 6}},
+		"Dedupe":    {Dedupe[int](), []int{1, 2, 3, 4, 5, 6}},
+		"Compose":   {Compose(Dedupe[int](), Filter(opr.Even[int])), []int{2, 4, 6}},
+		"Compose3":  {Compose3(Filter(opr.Odd[int]), Dedupe[int](), Map(opr.AddN(1))), []int{2, 4, 6}},
+	}
+	for name, test := range testcases {
+		if have := Collect(test.xf, data); !reflect.DeepEqual(have, test.expect) {
+			t.Errorf("%s: Collect(xf, %v) = %v, expected %v", name, data, have, test.expect)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	have := Collect(Window[int](3), []int{1, 2, 3, 4, 5})
+	expect := [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
+	if !reflect.DeepEqual(have, expect) {
+		t.Errorf("Collect(Window(3), [1 2 3 4 5]) = %v, expected %v", have, expect)
+	}
+	if have := Collect(Window[int](3), []int{1, 2}); len(have) != 0 {
+		t.Errorf("Collect(Window(3), [1 2]) = %v, expected []", have)
+	}
+	sum := func(xs []int) int { return opr.Add(xs[0], xs[1]) }
+	pairs := Collect(Compose(Window[int](2), Map(sum)), []int{1, 2, 3, 4})
+	if expect := []int{3, 5, 7}; !reflect.DeepEqual(pairs, expect) {
+		t.Errorf("pairwise sums = %v, expected %v", pairs, expect)
+	}
+}
+
+func TestSources(t *testing.T) {
+	xf := Compose3(Filter(opr.Even[int]), Map(strconv.Itoa), Take[string](3))
+	concat := opr.Add[string]
+	if have := TransduceSeq(xf, concat, "", naturals); have != "024" {
+		t.Errorf("TransduceSeq(xf, concat, naturals) = %q, expected \"024\"", have)
+	}
+	ch := make(chan int, 10)
+	for i := range 10 {
+		ch <- i
+	}
+	close(ch)
+	if have := TransduceChan(xf, concat, "", ch); have != "024" {
+		t.Errorf("TransduceChan(xf, concat, ch) = %q, expected \"024\"", have)
+	}
+	// Take stops after the fifth value (4), so the rest stays in the channel
+	if len(ch) != 5 {
+		t.Errorf("%d values left in channel, expected 5", len(ch))
+	}
+	if have := Transduce(xf, concat, ">", []int{1, 3, 5}); have != ">" {
+		t.Errorf("Transduce(xf, concat, [1 3 5]) = %q, expected \">\"", have)
+	}
+	have := slices.Collect(Seq(xf, naturals))
+	if expect := []string{"0", "2", "4"}; !reflect.DeepEqual(have, expect) {
+		t.Errorf("Seq(xf, naturals) = %v, expected %v", have, expect)
+	}
+}
+
+func TestEarlyTermination(t *testing.T) {
+	pulled := 0
+	counted := iters.Map(func(i int) int { pulled += 1; return i }, naturals)
+	TransduceSeq(Take[int](5), opr.Add[int], 0, counted)
+	if pulled != 5 {
+		t.Errorf("Take(5) pulled %d values, expected 5", pulled)
+	}
+	pulled = 0
+	for range Seq(Map(opr.AddN(1)), counted) {
+		break
+	}
+	if pulled != 1 {
+		t.Errorf("breaking from Seq pulled %d values, expected 1", pulled)
+	}
+	// Take(0) stops before the first value, also behind other transducers
+	for _, xf := range []Transducer[int, int]{Take[int](0), Compose(Map(opr.AddN(1)), Take[int](0))} {
+		pulled = 0
+		TransduceSeq(xf, opr.Add[int], 0, counted)
+		for range Seq(xf, counted) {
+		}
+		if pulled != 0 {
+			t.Errorf("Take(0) pulled %d values, expected 0", pulled)
+		}
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		if have := TransduceChan(xf, opr.Add[int], 0, ch); have != 0 || len(ch) != 3 {
+			t.Errorf("TransduceChan(Take(0), add, ch) = %d with %d values left, expected 0 with 3", have, len(ch))
+		}
+	}
+}
+
+func TestReuse(t *testing.T) {
+	xf := Compose(Dedupe[int](), Take[int](2))
+	for range 2 {
+		if have := Collect(xf, []int{7, 7, 8, 9}); !reflect.DeepEqual(have, []int{7, 8}) {
+			t.Errorf("Collect(xf, [7 7 8 9]) = %v, expected [7 8]", have)
+		}
+	}
+}
+
+func TestNoIntermediateAllocations(t *testing.T) {
+	xf := Compose3(Map(opr.MultiplyN(3)), Filter(opr.Odd[int]), Dedupe[int]())
+	small, large := make([]int, 10), make([]int, 10_000)
+	for i := range large {
+		large[i] = i
+	}
+	allocs := func(xs []int) float64 {
+		return testing.AllocsPerRun(10, func() { Transduce(xf, opr.Add[int], 0, xs) })
+	}
+	if a, b := allocs(small), allocs(large); a != b {
+		t.Errorf("allocations grow with the input: %v for 10 values, %v for 10000", a, b)
+	}
+}
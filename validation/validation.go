@@ -0,0 +1,285 @@
+// Package validation defines a validation type that accumulates all errors,
+// instead of stopping at the first one. This is useful to report every
+// problem with a form or a configuration at once.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	opr "github.com/basbiezemans/gofunctools/operators"
+	"golang.org/x/exp/constraints"
+)
+
+// A Validated either holds a valid value or the errors that were found while
+// validating it.
+type Validated[A any] struct {
+	value A
+	errs  Errors
+}
+
+// A FieldError is an error that occurred at a path in a (nested) value, e.g.
+// "address.city" or "tags[2]". The path is empty for the value itself.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+// Errors is a list of field errors. It implements the error interface.
+type Errors []FieldError
+
+// A Rule checks a value and returns an error if the value is invalid.
+type Rule[A any] func(A) error
+
+// Create a valid value.
+func Valid[A any](a A) Validated[A] {
+	return Validated[A]{value: a}
+}
+
+// Create an invalid value from one or more errors.
+func Invalid[A any](err error, errs ...error) Validated[A] {
+	var fes = make(Errors, 0, 1+len(errs))
+	for _, e := range append([]error{err}, errs...) {
+		fes = append(fes, FieldError{Err: e})
+	}
+	return Validated[A]{errs: fes}
+}
+
+// Validate applies all rules to a value and accumulates their errors.
+func Validate[A any](a A, rules ...Rule[A]) Validated[A] {
+	var errs Errors
+	for _, rule := range rules {
+		if err := rule(a); err != nil {
+			errs = append(errs, FieldError{Err: err})
+		}
+	}
+	return Validated[A]{a, errs}
+}
+
+// Field validates a named field. Errors are recorded with the field name as
+// their path.
+func Field[A any](name string, a A, rules ...Rule[A]) Validated[A] {
+	return At(name, Validate(a, rules...))
+}
+
+// At prefixes the paths of all errors with a field name, which is used to
+// validate nested values.
+func At[A any](name string, v Validated[A]) Validated[A] {
+	if len(v.errs) == 0 {
+		return v
+	}
+	var errs = make(Errors, len(v.errs))
+	for i, fe := range v.errs {
+		errs[i] = FieldError{joinPath(name, fe.Path), fe.Err}
+	}
+	return Validated[A]{v.value, errs}
+}
+
+// Map applies a unary function to a valid value.
+func Map[A, B any](fn func(A) B, va Validated[A]) Validated[B] {
+	if len(va.errs) > 0 {
+		return Validated[B]{errs: va.errs}
+	}
+	return Valid(fn(va.value))
+}
+
+// FlatMap applies a validating function to a valid value. Unlike the MapN
+// functions, it stops at the first invalid value.
+func FlatMap[A, B any](fn func(A) Validated[B], va Validated[A]) Validated[B] {
+	if len(va.errs) > 0 {
+		return Validated[B]{errs: va.errs}
+	}
+	return fn(va.value)
+}
+
+// Map2 applies a binary function, e.g. a constructor, to two valid values.
+// If any value is invalid, the errors of all values are accumulated.
+func Map2[A, B, C any](fn func(A, B) C, va Validated[A], vb Validated[B]) Validated[C] {
+	if errs := concat(va.errs, vb.errs); len(errs) > 0 {
+		return Validated[C]{errs: errs}
+	}
+	return Valid(fn(va.value, vb.value))
+}
+
+// Map3 is like Map2, but for a ternary function.
+func Map3[A, B, C, D any](fn func(A, B, C) D, va Validated[A], vb Validated[B], vc Validated[C]) Validated[D] {
+	if errs := concat(va.errs, vb.errs, vc.errs); len(errs) > 0 {
+		return Validated[D]{errs: errs}
+	}
+	return Valid(fn(va.value, vb.value, vc.value))
+}
+
+// Map4 is like Map2, but for a function of four arguments.
+func Map4[A, B, C, D, E any](fn func(A, B, C, D) E, va Validated[A], vb Validated[B], vc Validated[C], vd Validated[D]) Validated[E] {
+	if errs := concat(va.errs, vb.errs, vc.errs, vd.errs); len(errs) > 0 {
+		return Validated[E]{errs: errs}
+	}
+	return Valid(fn(va.value, vb.value, vc.value, vd.value))
+}
+
+// Map5 is like Map2, but for a function of five arguments.
+func Map5[A, B, C, D, E, F any](fn func(A, B, C, D, E) F, va Validated[A], vb Validated[B], vc Validated[C], vd Validated[D], ve Validated[E]) Validated[F] {
+	if errs := concat(va.errs, vb.errs, vc.errs, vd.errs, ve.errs); len(errs) > 0 {
+		return Validated[F]{errs: errs}
+	}
+	return Valid(fn(va.value, vb.value, vc.value, vd.value, ve.value))
+}
+
+// Map6 is like Map2, but for a function of six arguments.
+func Map6[A, B, C, D, E, F, G any](fn func(A, B, C, D, E, F) G, va Validated[A], vb Validated[B], vc Validated[C], vd Validated[D], ve Validated[E], vf Validated[F]) Validated[G] {
+	if errs := concat(va.errs, vb.errs, vc.errs, vd.errs, ve.errs, vf.errs); len(errs) > 0 {
+		return Validated[G]{errs: errs}
+	}
+	return Valid(fn(va.value, vb.value, vc.value, vd.value, ve.value, vf.value))
+}
+
+// Traverse applies a validating function to each element of a slice and
+// accumulates the errors of all elements. Errors are recorded with the index
+// of the element as their path, e.g. "[2]".
+func Traverse[A, B any](fn func(A) Validated[B], xs []A) Validated[[]B] {
+	var ys = make([]B, len(xs))
+	var errs Errors
+	for i, x := range xs {
+		v := At(fmt.Sprintf("[%d]", i), fn(x))
+		ys[i] = v.value
+		errs = append(errs, v.errs...)
+	}
+	if len(errs) > 0 {
+		return Validated[[]B]{errs: errs}
+	}
+	return Valid(ys)
+}
+
+// Sequence turns a slice of validated values into a validated slice.
+func Sequence[A any](vs []Validated[A]) Validated[[]A] {
+	return Traverse(func(v Validated[A]) Validated[A] { return v }, vs)
+}
+
+// Get returns the valid value, or the accumulated errors if it is invalid.
+func (v Validated[A]) Get() (A, error) {
+	if len(v.errs) > 0 {
+		var zero A
+		return zero, v.errs
+	}
+	return v.value, nil
+}
+
+// IsValid reports whether the value is valid.
+func (v Validated[A]) IsValid() bool {
+	return len(v.errs) == 0
+}
+
+// Errors returns the accumulated errors, or nil if the value is valid.
+func (v Validated[A]) Errors() Errors {
+	return v.errs
+}
+
+// Error formats the field errors as "path: message", separated by "; ".
+func (fe FieldError) Error() string {
+	if fe.Path == "" {
+		return fe.Err.Error()
+	}
+	return fe.Path + ": " + fe.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (fe FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// Error joins the messages of all field errors.
+func (errs Errors) Error() string {
+	var msgs = make([]string, len(errs))
+	for i, fe := range errs {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the field errors, so that errors.Is and errors.As can
+// inspect them.
+func (errs Errors) Unwrap() []error {
+	var es = make([]error, len(errs))
+	for i, fe := range errs {
+		es[i] = fe
+	}
+	return es
+}
+
+// Rules
+
+// Min checks that a value is at least n.
+func Min[T constraints.Ordered](n T) Rule[T] {
+	return check(func(x T) bool { return opr.GreaterThanOrEqual(x, n) }, "must be at least %v", n)
+}
+
+// Max checks that a value is at most n.
+func Max[T constraints.Ordered](n T) Rule[T] {
+	return check(func(x T) bool { return opr.LessThanOrEqual(x, n) }, "must be at most %v", n)
+}
+
+// Between checks that a value lies in the closed interval [lo, hi].
+func Between[T constraints.Ordered](lo, hi T) Rule[T] {
+	return check(func(x T) bool {
+		return opr.GreaterThanOrEqual(x, lo) && opr.LessThanOrEqual(x, hi)
+	}, "must be between %v and %v", lo, hi)
+}
+
+// NonEmpty checks that a string is not empty.
+func NonEmpty[T ~string](s T) error {
+	if len(s) == 0 {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+// NonEmptySlice checks that a slice is not empty.
+func NonEmptySlice[T any](xs []T) error {
+	if len(xs) == 0 {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+// Matches checks that a string matches a regular expression.
+func Matches(re *regexp.Regexp) Rule[string] {
+	return check(re.MatchString, "must match %s", re)
+}
+
+// Satisfies turns a predicate into a rule, which fails with the given message.
+func Satisfies[A any](fn func(A) bool, msg string) Rule[A] {
+	return check(fn, "%s", msg)
+}
+
+// Create a rule from a predicate and an error message.
+func check[A any](fn func(A) bool, format string, args ...any) Rule[A] {
+	return func(x A) error {
+		if !fn(x) {
+			return fmt.Errorf(format, args...)
+		}
+		return nil
+	}
+}
+
+// Join a parent path and a child path.
+func joinPath(parent, child string) string {
+	switch {
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	default:
+		return parent + "." + child
+	}
+}
+
+// Concatenate lists of field errors.
+func concat(errss ...Errors) Errors {
+	var errs Errors
+	for _, es := range errss {
+		errs = append(errs, es...)
+	}
+	return errs
+}
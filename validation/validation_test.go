@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type User struct {
+	Name    string
+	Email   string
+	Age     int
+	Address Address
+	Tags    []string
+}
+
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)
+
+func newAddress(street, city string) Address {
+	return Address{street, city}
+}
+
+func newUser(name, email string, age int, address Address, tags []string) User {
+	return User{name, email, age, address, tags}
+}
+
+func validateAddress(a Address) Validated[Address] {
+	return Map2(newAddress,
+		Field("street", a.Street, NonEmpty),
+		Field("city", a.City, NonEmpty),
+	)
+}
+
+func validateTag(tag string) Validated[string] {
+	return Validate(tag, NonEmpty, Max("zzz"))
+}
+
+func validateUser(u User) Validated[User] {
+	return Map5(newUser,
+		Field("name", u.Name, NonEmpty),
+		Field("email", u.Email, NonEmpty, Matches(emailRegexp)),
+		Field("age", u.Age, Between(0, 150)),
+		At("address", validateAddress(u.Address)),
+		At("tags", FlatMap(func(tags []string) Validated[[]string] {
+			return Traverse(validateTag, tags)
+		}, Validate(u.Tags, NonEmptySlice))),
+	)
+}
+
+func TestValid(t *testing.T) {
+	user := User{"Alice", "alice@example.com", 30, Address{"Main St", "Utrecht"}, []string{"admin"}}
+	have, err := validateUser(user).Get()
+	if err != nil || !reflect.DeepEqual(have, user) {
+		t.Errorf("validateUser(%v) = %v, %v, expected %v, <nil>", user, have, err, user)
+	}
+}
+
+func TestAccumulateErrors(t *testing.T) {
+	user := User{"", "alice", 200, Address{"", "Utrecht"}, []string{"ok", "", "zzzz"}}
+	v := validateUser(user)
+	if v.IsValid() {
+		t.Fatalf("validateUser(%v) is valid, expected errors", user)
+	}
+	var paths []string
+	for _, fe := range v.Errors() {
+		paths = append(paths, fe.Path)
+	}
+	want := []string{"name", "email", "age", "address.street", "tags[1]", "tags[2]"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("error paths = %v, expected %v", paths, want)
+	}
+	_, err := v.Get()
+	msg := "name: must not be empty; email: must match ^[^@\\s]+@[^@\\s]+$; " +
+		"age: must be between 0 and 150; address.street: must not be empty; " +
+		"tags[1]: must not be empty; tags[2]: must be at most zzz"
+	if err.Error() != msg {
+		t.Errorf("error = %q, expected %q", err.Error(), msg)
+	}
+}
+
+func TestMultipleRuleErrors(t *testing.T) {
+	v := Field("code", "", NonEmpty, Matches(regexp.MustCompile(`^\d+$`)))
+	if n := len(v.Errors()); n != 2 {
+		t.Errorf("Field(\"code\", \"\", ...) has %d errors, expected 2: %v", n, v.Errors())
+	}
+}
+
+func TestErrorsIs(t *testing.T) {
+	errTaken := errors.New("already taken")
+	v := At("user", Field("name", "root", Satisfies(func(s string) bool { return s != "root" }, "reserved")))
+	v = Map2(func(s string, _ string) string { return s }, v, At("user", Invalid[string](errTaken)))
+	_, err := v.Get()
+	if !errors.Is(err, errTaken) {
+		t.Errorf("errors.Is(%v, errTaken) = false, expected true", err)
+	}
+	var fe FieldError
+	if !errors.As(err, &fe) || fe.Path != "user.name" {
+		t.Errorf("errors.As(%v, &fe) = %v, expected path user.name", err, fe)
+	}
+	if msg := err.Error(); msg != "user.name: reserved; user: already taken" {
+		t.Errorf("error = %q", msg)
+	}
+}
+
+func TestMapFlatMap(t *testing.T) {
+	parse := func(s string) Validated[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Invalid[int](err)
+		}
+		return Valid(n)
+	}
+	have := Map(strconv.Itoa, FlatMap(parse, Field("n", "12", NonEmpty)))
+	if s, err := have.Get(); err != nil || s != "12" {
+		t.Errorf("Map(Itoa, FlatMap(parse, \"12\")) = %v, %v, expected 12, <nil>", s, err)
+	}
+	if FlatMap(parse, Valid("x")).IsValid() {
+		t.Errorf("FlatMap(parse, Valid(\"x\")) is valid, expected invalid")
+	}
+}
+
+func TestSequence(t *testing.T) {
+	vs := []Validated[int]{Valid(1), Field("n", -1, Min(0)), Valid(3), Validate(10, Max(5))}
+	v := Sequence(vs)
+	var paths []string
+	for _, fe := range v.Errors() {
+		paths = append(paths, fe.Path)
+	}
+	if want := []string{"[1].n", "[3]"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("error paths = %v, expected %v", paths, want)
+	}
+	have, err := Sequence([]Validated[int]{Valid(1), Valid(2)}).Get()
+	if err != nil || !reflect.DeepEqual(have, []int{1, 2}) {
+		t.Errorf("Sequence(Valid(1), Valid(2)) = %v, %v, expected [1 2], <nil>", have, err)
+	}
+}
+
+func TestMap6(t *testing.T) {
+	sum := func(a, b, c, d, e, f int) int { return a + b + c + d + e + f }
+	have, err := Map6(sum, Valid(1), Valid(2), Valid(3), Valid(4), Valid(5), Valid(6)).Get()
+	if err != nil || have != 21 {
+		t.Errorf("Map6(sum, 1..6) = %v, %v, expected 21, <nil>", have, err)
+	}
+	v := Map6(sum, Valid(1), Invalid[int](errors.New("b")), Valid(3), Valid(4), Valid(5), Invalid[int](errors.New("f")))
+	if n := len(v.Errors()); n != 2 {
+		t.Errorf("Map6 has %d errors, expected 2", n)
+	}
+}
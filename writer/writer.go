@@ -0,0 +1,79 @@
+// Package writer defines computations that produce a value together with a
+// log, such as an audit trail. The logs of chained computations are
+// concatenated in order.
+package writer
+
+import "slices"
+
+// A Writer is a value of type A with a log of entries of type W.
+type Writer[W, A any] struct {
+	value A
+	log   []W
+}
+
+// Create a value with a log.
+func New[W, A any](a A, log ...W) Writer[W, A] {
+	return Writer[W, A]{a, log}
+}
+
+// Of creates a value with an empty log.
+func Of[W, A any](a A) Writer[W, A] {
+	return Writer[W, A]{value: a}
+}
+
+// Tell writes entries to the log.
+func Tell[W any](log ...W) Writer[W, struct{}] {
+	return Writer[W, struct{}]{log: log}
+}
+
+// Run returns the value and the log.
+func (w Writer[W, A]) Run() (A, []W) {
+	return w.value, w.log
+}
+
+// Value returns the value without the log.
+func (w Writer[W, A]) Value() A {
+	return w.value
+}
+
+// Log returns the log without the value.
+func (w Writer[W, A]) Log() []W {
+	return w.log
+}
+
+// Map applies a unary function to the value. The log is unchanged.
+func Map[W, A, B any](fn func(A) B, w Writer[W, A]) Writer[W, B] {
+	return Writer[W, B]{fn(w.value), w.log}
+}
+
+// FlatMap chains a computation to the value. The log of the result is the log
+// of w followed by the log of the computation.
+func FlatMap[W, A, B any](fn func(A) Writer[W, B], w Writer[W, A]) Writer[W, B] {
+	var next = fn(w.value)
+	return Writer[W, B]{next.value, slices.Concat(w.log, next.log)}
+}
+
+// Sequence collects the values of a slice of writers and concatenates their
+// logs.
+func Sequence[W, A any](ws []Writer[W, A]) Writer[W, []A] {
+	var xs = make([]A, len(ws))
+	var log []W
+	for i, w := range ws {
+		xs[i] = w.value
+		log = append(log, w.log...)
+	}
+	return Writer[W, []A]{xs, log}
+}
+
+// Traverse applies a function, which returns a writer, to each element of a
+// slice, and collects the values and logs.
+func Traverse[W, A, B any](fn func(A) Writer[W, B], xs []A) Writer[W, []B] {
+	var ys = make([]B, len(xs))
+	var log []W
+	for i, x := range xs {
+		w := fn(x)
+		ys[i] = w.value
+		log = append(log, w.log...)
+	}
+	return Writer[W, []B]{ys, log}
+}
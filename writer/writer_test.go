@@ -0,0 +1,48 @@
+package writer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func parse(s string) Writer[string, int] {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return New(0, fmt.Sprintf("invalid %q, using 0", s))
+	}
+	return New(n, "parsed "+s)
+}
+
+func TestWriter(t *testing.T) {
+	w := FlatMap(func(n int) Writer[string, int] {
+		return FlatMap(func(struct{}) Writer[string, int] {
+			return Of[string](n * 2)
+		}, Tell("doubled"))
+	}, parse("21"))
+	value, log := w.Run()
+	if expect := []string{"parsed 21", "doubled"}; value != 42 || !reflect.DeepEqual(log, expect) {
+		t.Errorf("Run() = %d, %v, expected 42, %v", value, log, expect)
+	}
+	if have := Map(strconv.Itoa, w); have.Value() != "42" || len(have.Log()) != 2 {
+		t.Errorf("Map(Itoa, w) = %v, %v", have.Value(), have.Log())
+	}
+	if log := Of[string](1).Log(); len(log) != 0 {
+		t.Errorf("Of(1).Log() = %v, expected []", log)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	values, log := Traverse(parse, []string{"1", "x", "3"}).Run()
+	if expect := []int{1, 0, 3}; !reflect.DeepEqual(values, expect) {
+		t.Errorf("Traverse values = %v, expected %v", values, expect)
+	}
+	if expect := []string{"parsed 1", `invalid "x", using 0`, "parsed 3"}; !reflect.DeepEqual(log, expect) {
+		t.Errorf("Traverse log = %v, expected %v", log, expect)
+	}
+	values, log = Sequence([]Writer[string, int]{New(1, "a"), New(2, "b", "c")}).Run()
+	if !reflect.DeepEqual(values, []int{1, 2}) || !reflect.DeepEqual(log, []string{"a", "b", "c"}) {
+		t.Errorf("Sequence = %v, %v", values, log)
+	}
+}